@@ -0,0 +1,140 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// AdminUsersUnsupportedVersionsExportParams contains arguments for
+// AdminUsersUnsupportedVersionsExport method calls.
+type AdminUsersUnsupportedVersionsExportParams struct {
+	// DateEndOfSupport is the unix timestamp of the date of past or upcoming end of
+	// support cycles. If omitted, Slack uses the next upcoming end of support date.
+	DateEndOfSupport int64
+	// DateSessionsStarted is the unix timestamp of a date to start looking for user
+	// sessions. If omitted, Slack uses the start of the previous day.
+	DateSessionsStarted int64
+}
+
+// AdminUsersUnsupportedVersionsExport asks Slack to export a CSV of the users of the
+// organisation running unsupported versions of the Slack client. The export is
+// delivered to the calling user through a direct message from Slackbot.
+// See: https://api.slack.com/methods/admin.users.unsupportedVersions.export
+func (api *Client) AdminUsersUnsupportedVersionsExport(ctx context.Context, params AdminUsersUnsupportedVersionsExportParams) error {
+	values := url.Values{
+		"token": {api.token},
+	}
+
+	if params.DateEndOfSupport != 0 {
+		values.Add("date_end_of_support", strconv.FormatInt(params.DateEndOfSupport, 10))
+	}
+
+	if params.DateSessionsStarted != 0 {
+		values.Add("date_sessions_started", strconv.FormatInt(params.DateSessionsStarted, 10))
+	}
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.users.unsupportedVersions.export", values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminUser is a user as returned by the admin.users.* methods. Unlike User, it
+// always carries the email address of the account.
+type AdminUser struct {
+	ID                string   `json:"id"`
+	Email             string   `json:"email"`
+	Username          string   `json:"username"`
+	FullName          string   `json:"full_name"`
+	IsAdmin           bool     `json:"is_admin"`
+	IsOwner           bool     `json:"is_owner"`
+	IsPrimaryOwner    bool     `json:"is_primary_owner"`
+	IsRestricted      bool     `json:"is_restricted"`
+	IsUltraRestricted bool     `json:"is_ultra_restricted"`
+	IsBot             bool     `json:"is_bot"`
+	IsActive          bool     `json:"is_active"`
+	Has2FA            bool     `json:"has_2fa"`
+	HasSSO            bool     `json:"has_sso"`
+	DateCreated       JSONTime `json:"date_created"`
+	DeactivatedTs     JSONTime `json:"deactivated_ts"`
+	ReactivatedTs     JSONTime `json:"reactivated_ts"`
+	Expiration        JSONTime `json:"expiration_ts"`
+	Workspaces        []string `json:"workspaces"`
+}
+
+// AdminUsersListParams contains arguments for AdminUsersList method calls.
+type AdminUsersListParams struct {
+	// TeamID restricts the listing to a single workspace. Required unless the
+	// token is an org-level token.
+	TeamID                           string
+	Cursor                           string
+	Limit                            int
+	IncludeDeactivatedUserWorkspaces bool
+	IsActive                         *bool
+}
+
+// AdminUsersList lists the users of a workspace or organisation, including their
+// email addresses, and returns the cursor for the next page of results.
+// See: https://api.slack.com/methods/admin.users.list
+func (api *Client) AdminUsersList(ctx context.Context, params AdminUsersListParams) ([]AdminUser, string, error) {
+	values := url.Values{
+		"token": {api.token},
+	}
+
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	if params.IncludeDeactivatedUserWorkspaces {
+		values.Add("include_deactivated_user_workspaces", "true")
+	}
+
+	if params.IsActive != nil {
+		values.Add("is_active", strconv.FormatBool(*params.IsActive))
+	}
+
+	response := struct {
+		SlackResponse
+		Users []AdminUser `json:"users"`
+	}{}
+	err := api.postMethod(ctx, "admin.users.list", values, &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.Users, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// AdminUsersEmails walks every page of admin.users.list and returns a map of user
+// IDs to email addresses, which is handy for compliance reports that only need
+// the addresses of the accounts.
+func (api *Client) AdminUsersEmails(ctx context.Context, params AdminUsersListParams) (map[string]string, error) {
+	emails := make(map[string]string)
+	for {
+		users, cursor, err := api.AdminUsersList(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			emails[user.ID] = user.Email
+		}
+
+		if cursor == "" {
+			return emails, nil
+		}
+		params.Cursor = cursor
+	}
+}
@@ -0,0 +1,68 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminUsersUnsupportedVersionsExport(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/admin.users.unsupportedVersions.export", func(rw http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error: %s", err)
+			return
+		}
+
+		assert.Equal(t, "1700000000", r.Form.Get("date_end_of_support"))
+		assert.Equal(t, "", r.Form.Get("date_sessions_started"))
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":true}`))
+	})
+	once.Do(startServer)
+	api := New("testing-token", OptionAPIURL("http://"+serverAddr+"/"))
+
+	err := api.AdminUsersUnsupportedVersionsExport(context.Background(), AdminUsersUnsupportedVersionsExportParams{
+		DateEndOfSupport: 1700000000,
+	})
+	require.NoError(t, err)
+}
+
+func TestAdminUsersEmails(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/admin.users.list", func(rw http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error: %s", err)
+			return
+		}
+
+		assert.Equal(t, "T123", r.Form.Get("team_id"))
+
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.Form.Get("cursor") {
+		case "":
+			rw.Write([]byte(`{"ok":true,"users":[{"id":"U1","email":"one@example.com","is_admin":true,"date_created":1600000000}],"response_metadata":{"next_cursor":"page2"}}`))
+		case "page2":
+			rw.Write([]byte(`{"ok":true,"users":[{"id":"U2","email":"two@example.com"}],"response_metadata":{"next_cursor":""}}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.Form.Get("cursor"))
+		}
+	})
+	once.Do(startServer)
+	api := New("testing-token", OptionAPIURL("http://"+serverAddr+"/"))
+
+	users, cursor, err := api.AdminUsersList(context.Background(), AdminUsersListParams{TeamID: "T123"})
+	require.NoError(t, err)
+	assert.Equal(t, "page2", cursor)
+	require.Len(t, users, 1)
+	assert.True(t, users[0].IsAdmin)
+	assert.Equal(t, JSONTime(1600000000), users[0].DateCreated)
+
+	emails, err := api.AdminUsersEmails(context.Background(), AdminUsersListParams{TeamID: "T123"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"U1": "one@example.com", "U2": "two@example.com"}, emails)
+}
@@ -96,3 +96,31 @@ type Attachment struct {
 
 	Ts json.Number `json:"ts,omitempty"`
 }
+
+// UnmarshalJSON implements the Unmarshaller interface for Attachment. Attachments are often
+// built by other apps and integrations, so a block that cannot be decoded into its known type
+// does not fail the whole attachment: it is kept as an UnknownBlock whose Err reports why.
+func (a *Attachment) UnmarshalJSON(data []byte) error {
+	type alias Attachment
+	aux := struct {
+		*alias
+		Blocks json.RawMessage `json:"blocks,omitempty"`
+	}{alias: (*alias)(a)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	a.Blocks = Blocks{}
+	if len(aux.Blocks) == 0 || string(aux.Blocks) == "null" {
+		return nil
+	}
+
+	blocks, err := unmarshalBlocks(aux.Blocks, true)
+	if err != nil {
+		return err
+	}
+	a.Blocks = blocks
+
+	return nil
+}
@@ -2,6 +2,7 @@ package slack
 
 import (
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 
@@ -71,3 +72,126 @@ func TestAttachment_UnmarshalMarshalJSON_WithBlocks(t *testing.T) {
 		t.Fatal("actual does not match expected\n", strings.Join(diff, "\n"))
 	}
 }
+
+func TestAttachment_UnmarshalJSON_WithNewerBlockTypes(t *testing.T) {
+	attachmentJson := `{
+    "id": 1,
+    "color": "#2eb886",
+    "fallback": "Deploy finished",
+    "blocks": [
+      {
+        "type": "section",
+        "block_id": "summary",
+        "text": {"type": "mrkdwn", "text": "*Deploy finished*"},
+        "accessory": {
+          "type": "workflow_button",
+          "text": {"type": "plain_text", "text": "Roll back"},
+          "workflow": {"trigger": {"url": "https://slack.com/shortcuts/Ft0123/abc"}}
+        }
+      },
+      {
+        "type": "context",
+        "block_id": "meta",
+        "elements": [
+          {"type": "mrkdwn", "text": "Started by"},
+          {"type": "user", "user_id": "U123ABC456"}
+        ]
+      },
+      {
+        "type": "actions",
+        "block_id": "links",
+        "elements": [
+          {"type": "button", "action_id": "view", "text": {"type": "plain_text", "text": "View"}},
+          {"type": "icon_button", "action_id": "more", "icon": "trash"}
+        ]
+      },
+      {
+        "type": "table",
+        "block_id": "results",
+        "rows": [[{"type": "raw_text", "text": "ok"}]]
+      }
+    ]
+  }`
+
+	attachment := new(Attachment)
+	if err := json.Unmarshal([]byte(attachmentJson), attachment); err != nil {
+		t.Fatalf("expected no error unmarshaling attachment with newer block types, got: %v", err)
+	}
+
+	blocks := attachment.Blocks.BlockSet
+	if len(blocks) != 4 {
+		t.Fatalf("expected 4 blocks, got %d", len(blocks))
+	}
+
+	section, ok := blocks[0].(*SectionBlock)
+	if !ok || section.Accessory.UnknownElement == nil {
+		t.Errorf("expected section block with an unknown accessory, got %#v", blocks[0])
+	}
+
+	context, ok := blocks[1].(*ContextBlock)
+	if !ok || len(context.ContextElements.Elements) != 2 {
+		t.Errorf("expected context block with 2 elements, got %#v", blocks[1])
+	}
+
+	actions, ok := blocks[2].(*ActionBlock)
+	if !ok || len(actions.Elements.ElementSet) != 2 {
+		t.Errorf("expected actions block with 2 elements, got %#v", blocks[2])
+	}
+
+	// The table block type is unknown and is preserved as is.
+	if _, ok := blocks[3].(*UnknownBlock); !ok {
+		t.Errorf("expected block 3 to be an UnknownBlock, got %#v", blocks[3])
+	}
+
+	actualAttachmentJson, err := json.Marshal(attachment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected, actual map[string]interface{}
+	json.Unmarshal([]byte(attachmentJson), &expected)
+	json.Unmarshal(actualAttachmentJson, &actual)
+	if diff := deep.Equal(expected["blocks"].([]interface{})[3:], actual["blocks"].([]interface{})[3:]); diff != nil {
+		t.Errorf("unknown blocks did not round trip: %v", diff)
+	}
+}
+
+func TestAttachment_UnmarshalJSON_WithMalformedKnownBlock(t *testing.T) {
+	attachmentJson := `{
+    "id": 1,
+    "blocks": [
+      {"type": "table", "block_id": "results", "rows": []},
+      {"type": "section", "block_id": "summary", "text": "not a text object"}
+    ]
+  }`
+
+	attachment := new(Attachment)
+	if err := json.Unmarshal([]byte(attachmentJson), attachment); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(attachment.Blocks.BlockSet) != 2 {
+		t.Fatalf("expected 2 blocks, got %d", len(attachment.Blocks.BlockSet))
+	}
+	if table := attachment.Blocks.BlockSet[0].(*UnknownBlock); table.Err != nil {
+		t.Errorf("unexpected error for a block of unknown type: %s", table.Err)
+	}
+
+	section, ok := attachment.Blocks.BlockSet[1].(*UnknownBlock)
+	if !ok {
+		t.Fatalf("expected the malformed section to be kept as an unknown block, got %T", attachment.Blocks.BlockSet[1])
+	}
+	var decodeErr *BlockDecodeError
+	if !errors.As(section.Err, &decodeErr) || decodeErr.Index != 1 || decodeErr.Type != MBTSection {
+		t.Errorf("expected a decode error for the section block, got %#v", section.Err)
+	}
+	if section.BlockID != "summary" || len(section.Raw) == 0 {
+		t.Errorf("expected the malformed section to keep its ID and payload, got %#v", section)
+	}
+
+	// Outside of attachments, the decode error is returned.
+	var blocks Blocks
+	err := json.Unmarshal([]byte(`[{"type": "section", "text": "not a text object"}]`), &blocks)
+	if !errors.As(err, &decodeErr) || decodeErr.Index != 0 {
+		t.Errorf("expected a decode error, got %v", err)
+	}
+}
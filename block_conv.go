@@ -2,7 +2,6 @@ package slack
 
 import (
 	"encoding/json"
-	"fmt"
 )
 
 type sumtype struct {
@@ -21,25 +20,40 @@ func (b Blocks) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements the Unmarshaller interface for Blocks, so that any JSON
-// unmarshalling is delegated and proper type determination can be made before unmarshal
+// unmarshalling is delegated and proper type determination can be made before unmarshal.
+// Blocks of unknown types are preserved as UnknownBlock values holding the raw JSON, and
+// a block that cannot be decoded into its known type fails with a *BlockDecodeError.
 func (b *Blocks) UnmarshalJSON(data []byte) error {
+	blocks, err := unmarshalBlocks(data, false)
+	if err != nil {
+		return err
+	}
+
+	*b = blocks
+	return nil
+}
+
+// unmarshalBlocks decodes a list of blocks. When keepMalformed is true, a block that cannot be
+// decoded into its known type is kept as an UnknownBlock whose Err is the *BlockDecodeError,
+// instead of failing the whole list.
+func unmarshalBlocks(data []byte, keepMalformed bool) (Blocks, error) {
 	var raw []json.RawMessage
 
 	if string(data) == "{}" {
-		return nil
+		return Blocks{}, nil
 	}
 
 	err := json.Unmarshal(data, &raw)
 	if err != nil {
-		return err
+		return Blocks{}, err
 	}
 
 	var blocks Blocks
-	for _, r := range raw {
+	for i, r := range raw {
 		s := sumtype{}
 		err := json.Unmarshal(r, &s)
 		if err != nil {
-			return err
+			return Blocks{}, err
 		}
 
 		var blockType string
@@ -81,14 +95,23 @@ func (b *Blocks) UnmarshalJSON(data []byte) error {
 
 		err = json.Unmarshal(r, block)
 		if err != nil {
-			return err
+			decodeErr := &BlockDecodeError{Index: i, Type: MessageBlockType(blockType), Err: err}
+			if !keepMalformed {
+				return Blocks{}, decodeErr
+			}
+
+			unknown := &UnknownBlock{}
+			if err := json.Unmarshal(r, unknown); err != nil {
+				return Blocks{}, decodeErr
+			}
+			unknown.Err = decodeErr
+			block = unknown
 		}
 
 		blocks.BlockSet = append(blocks.BlockSet, block)
 	}
 
-	*b = blocks
-	return nil
+	return blocks, nil
 }
 
 // UnmarshalJSON implements the Unmarshaller interface for InputBlock, so that any JSON
@@ -142,7 +165,7 @@ func (b *InputBlock) UnmarshalJSON(data []byte) error {
 	case "file_input":
 		e = &FileInputBlockElement{}
 	default:
-		return fmt.Errorf("unsupported block element type %v", s.TypeVal)
+		e = &UnknownBlockElement{}
 	}
 
 	if err := json.Unmarshal(a.Element, e); err != nil {
@@ -222,7 +245,7 @@ func (b *BlockElements) UnmarshalJSON(data []byte) error {
 		case "number_input":
 			blockElement = &NumberInputBlockElement{}
 		default:
-			return fmt.Errorf("unsupported block element type %v", blockElementType)
+			blockElement = &UnknownBlockElement{}
 		}
 
 		err = json.Unmarshal(r, blockElement)
@@ -352,6 +375,38 @@ func (a *Accessory) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// UnmarshalJSON implements the Unmarshaller interface for UnknownBlockElement,
+// keeping a copy of the raw payload alongside the decoded type.
+func (s *UnknownBlockElement) UnmarshalJSON(data []byte) error {
+	var e struct {
+		Type     MessageElementType `json:"type"`
+		Elements json.RawMessage    `json:"elements"`
+	}
+	if err := json.Unmarshal(data, &e); err != nil {
+		return err
+	}
+
+	*s = UnknownBlockElement{Type: e.Type, Raw: append(json.RawMessage(nil), data...)}
+	// Nothing is known about the shape of unknown elements, Elements is only filled in
+	// when it happens to hold block elements.
+	var elements BlockElements
+	if len(e.Elements) > 0 && json.Unmarshal(e.Elements, &elements) == nil {
+		s.Elements = elements
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the Marshaller interface for UnknownBlockElement. If the
+// element was decoded from JSON, the original payload is returned as is.
+func (s UnknownBlockElement) MarshalJSON() ([]byte, error) {
+	if len(s.Raw) > 0 {
+		return s.Raw, nil
+	}
+	type alias UnknownBlockElement
+	return json.Marshal(alias(s))
+}
+
 func unmarshalBlockElement(r json.RawMessage, element BlockElement) (BlockElement, error) {
 	err := json.Unmarshal(r, element)
 	if err != nil {
@@ -391,6 +446,12 @@ func toBlockElement(element *Accessory) BlockElement {
 	if element.MultiSelectElement != nil {
 		return element.MultiSelectElement
 	}
+	if element.RichTextInputElement != nil {
+		return element.RichTextInputElement
+	}
+	if element.UnknownElement != nil {
+		return element.UnknownElement
+	}
 
 	return nil
 }
@@ -448,7 +509,12 @@ func (e *ContextElements) UnmarshalJSON(data []byte) error {
 
 			e.Elements = append(e.Elements, elem.(*ImageBlockElement))
 		default:
-			return fmt.Errorf("unsupported context element type %v", contextElementType)
+			elem, err := unmarshalBlockElement(r, &UnknownBlockElement{})
+			if err != nil {
+				return err
+			}
+
+			e.Elements = append(e.Elements, elem.(*UnknownBlockElement))
 		}
 	}
 
@@ -1,5 +1,7 @@
 package slack
 
+import "encoding/json"
+
 // https://api.slack.com/reference/messaging/block-elements
 
 const (
@@ -101,9 +103,11 @@ type BlockElements struct {
 // See the "Rich Elements" section at the following URL:
 // https://api.slack.com/changelog/2019-09-what-they-see-is-what-you-get-and-more-and-less
 // New block element types may be introduced by Slack at any time; this is a catch-all for any such block elements.
+// The original JSON payload is preserved in Raw so that the element can be marshalled back unchanged.
 type UnknownBlockElement struct {
 	Type     MessageElementType `json:"type"`
 	Elements BlockElements
+	Raw      json.RawMessage `json:"-"`
 }
 
 // ElementType returns the type of the Element
@@ -111,6 +115,12 @@ func (s UnknownBlockElement) ElementType() MessageElementType {
 	return s.Type
 }
 
+// MixedElementType returns the type of the Element, allowing unknown elements
+// to be carried inside context blocks.
+func (s UnknownBlockElement) MixedElementType() MixedElementType {
+	return MixedElementType(s.Type)
+}
+
 // ImageBlockElement An element to insert an image - this element can be used
 // in section and context blocks only. If you want a block with only an image
 // in it, you're looking for the image block.
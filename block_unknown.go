@@ -1,10 +1,38 @@
 package slack
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // UnknownBlock represents a block type that is not yet known. This block type exists to prevent Slack from introducing
 // new and unknown block types that break this library.
+//
+// The original JSON payload is preserved in Raw so that unknown blocks survive
+// a round trip through Unmarshal and Marshal unchanged.
 type UnknownBlock struct {
 	Type    MessageBlockType `json:"type"`
 	BlockID string           `json:"block_id,omitempty"`
+	Raw     json.RawMessage  `json:"-"`
+	// Err is set when the block has a known type but could not be decoded into it,
+	// which only happens for the blocks of attachments. It is a *BlockDecodeError.
+	Err error `json:"-"`
+}
+
+// BlockDecodeError is returned when a block of a known type cannot be decoded into it.
+type BlockDecodeError struct {
+	// Index is the position of the block in its list.
+	Index int
+	Type  MessageBlockType
+	Err   error
+}
+
+func (e *BlockDecodeError) Error() string {
+	return fmt.Sprintf("failed to decode %s block at index %d: %v", e.Type, e.Index, e.Err)
+}
+
+func (e *BlockDecodeError) Unwrap() error {
+	return e.Err
 }
 
 // BlockType returns the type of the block
@@ -16,3 +44,26 @@ func (b UnknownBlock) BlockType() MessageBlockType {
 func (s UnknownBlock) ID() string {
 	return s.BlockID
 }
+
+// UnmarshalJSON implements the Unmarshaller interface for UnknownBlock, keeping
+// a copy of the raw payload alongside the decoded type and block ID.
+func (b *UnknownBlock) UnmarshalJSON(data []byte) error {
+	type alias UnknownBlock
+	var a alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+	a.Raw = append(json.RawMessage(nil), data...)
+	*b = UnknownBlock(a)
+	return nil
+}
+
+// MarshalJSON implements the Marshaller interface for UnknownBlock. If the block
+// was decoded from JSON, the original payload is returned as is.
+func (b UnknownBlock) MarshalJSON() ([]byte, error) {
+	if len(b.Raw) > 0 {
+		return b.Raw, nil
+	}
+	type alias UnknownBlock
+	return json.Marshal(alias(b))
+}
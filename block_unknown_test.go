@@ -0,0 +1,96 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownBlockRoundTrip(t *testing.T) {
+	payload := `[{"type":"future_block","block_id":"fb1","payload":{"value":42}}]`
+
+	var blocks Blocks
+	require.NoError(t, json.Unmarshal([]byte(payload), &blocks))
+	require.Len(t, blocks.BlockSet, 1)
+
+	block, ok := blocks.BlockSet[0].(*UnknownBlock)
+	require.True(t, ok)
+	assert.Equal(t, MessageBlockType("future_block"), block.BlockType())
+	assert.Equal(t, "fb1", block.ID())
+
+	out, err := json.Marshal(blocks)
+	require.NoError(t, err)
+	assert.JSONEq(t, payload, string(out))
+}
+
+func TestUnknownBlockMarshalWithoutRaw(t *testing.T) {
+	out, err := json.Marshal(UnknownBlock{Type: "future_block", BlockID: "fb1"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"future_block","block_id":"fb1"}`, string(out))
+}
+
+func TestUnknownBlockElementRoundTrip(t *testing.T) {
+	payload := `[
+		{"type":"section","text":{"type":"mrkdwn","text":"hi"},"accessory":{"type":"future_accessory","action_id":"a1"}},
+		{"type":"actions","block_id":"b2","elements":[{"type":"future_element","action_id":"a2","extra":true}]},
+		{"type":"context","elements":[{"type":"future_context","text":"x"}]},
+		{"type":"input","block_id":"b4","label":{"type":"plain_text","text":"l"},"element":{"type":"future_input","action_id":"a4"}}
+	]`
+
+	var blocks Blocks
+	require.NoError(t, json.Unmarshal([]byte(payload), &blocks))
+	require.Len(t, blocks.BlockSet, 4)
+
+	section := blocks.BlockSet[0].(*SectionBlock)
+	require.NotNil(t, section.Accessory.UnknownElement)
+	assert.Equal(t, MessageElementType("future_accessory"), section.Accessory.UnknownElement.ElementType())
+
+	actions := blocks.BlockSet[1].(*ActionBlock)
+	require.Len(t, actions.Elements.ElementSet, 1)
+	assert.Equal(t, MessageElementType("future_element"), actions.Elements.ElementSet[0].ElementType())
+
+	context := blocks.BlockSet[2].(*ContextBlock)
+	require.Len(t, context.ContextElements.Elements, 1)
+	assert.Equal(t, MixedElementType("future_context"), context.ContextElements.Elements[0].MixedElementType())
+
+	input := blocks.BlockSet[3].(*InputBlock)
+	assert.Equal(t, MessageElementType("future_input"), input.Element.ElementType())
+
+	out, err := json.Marshal(blocks)
+	require.NoError(t, err)
+
+	var roundTrip Blocks
+	require.NoError(t, json.Unmarshal(out, &roundTrip))
+	assert.Equal(t, blocks, roundTrip)
+	assert.Contains(t, string(out), `{"type":"future_element","action_id":"a2","extra":true}`)
+	assert.Contains(t, string(out), `{"type":"future_accessory","action_id":"a1"}`)
+}
+
+func TestUnknownBlockElementWithUnexpectedElements(t *testing.T) {
+	payload := `[
+		{"type":"actions","elements":[
+			{"type":"future","elements":{"a":1}},
+			{"type":"future","elements":"text"},
+			{"type":"future","elements":42},
+			{"type":"future","elements":[{"type":"button","text":{"type":"plain_text","text":"b"}}]}
+		]}
+	]`
+
+	var blocks Blocks
+	require.NoError(t, json.Unmarshal([]byte(payload), &blocks))
+
+	actions := blocks.BlockSet[0].(*ActionBlock)
+	require.Len(t, actions.Elements.ElementSet, 4)
+	for _, element := range actions.Elements.ElementSet[:3] {
+		unknown := element.(*UnknownBlockElement)
+		assert.Equal(t, MessageElementType("future"), unknown.Type)
+		assert.Empty(t, unknown.Elements.ElementSet)
+	}
+	assert.Len(t, actions.Elements.ElementSet[3].(*UnknownBlockElement).Elements.ElementSet, 1)
+
+	out, err := json.Marshal(blocks)
+	require.NoError(t, err)
+	assert.JSONEq(t, payload, string(out))
+}
@@ -224,7 +224,17 @@ func (api *Client) SendMessageContext(ctx context.Context, channelID string, opt
 		response chatResponseFull
 	)
 
-	if req, parser, err = buildSender(api.endpoint, options...).BuildRequestContext(ctx, api.token, channelID); err != nil {
+	config, err := applyMsgOptions(api.token, channelID, api.endpoint, options...)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if err = api.checkMentionPolicy(ctx, channelID, config); err != nil {
+		return "", "", "", err
+	}
+
+	config.jsonRequests = api.jsonRequests
+	if req, parser, err = config.buildRequestContext(ctx, api.token); err != nil {
 		return "", "", "", err
 	}
 
@@ -289,13 +299,6 @@ func applyMsgOptions(token, channel, apiurl string, options ...MsgOption) (sendC
 	return config, nil
 }
 
-func buildSender(apiurl string, options ...MsgOption) sendConfig {
-	return sendConfig{
-		apiurl:  apiurl,
-		options: options,
-	}
-}
-
 type sendMode string
 
 const (
@@ -321,6 +324,8 @@ type sendConfig struct {
 	responseType    string
 	replaceOriginal bool
 	deleteOriginal  bool
+	jsonRequests    bool
+	nameResolvers   *nameResolvers
 }
 
 func (t sendConfig) BuildRequest(token, channelID string) (req *http.Request, _ func(*chatResponseFull) responseParser, err error) {
@@ -328,9 +333,24 @@ func (t sendConfig) BuildRequest(token, channelID string) (req *http.Request, _
 }
 
 func (t sendConfig) BuildRequestContext(ctx context.Context, token, channelID string) (req *http.Request, _ func(*chatResponseFull) responseParser, err error) {
+	jsonRequests := t.jsonRequests
 	if t, err = applyMsgOptions(token, channelID, t.apiurl, t.options...); err != nil {
 		return nil, nil, err
 	}
+	t.jsonRequests = jsonRequests
+
+	return t.buildRequestContext(ctx, token)
+}
+
+// buildRequestContext builds the request of a sendConfig whose options are already applied.
+func (t sendConfig) buildRequestContext(ctx context.Context, token string) (req *http.Request, _ func(*chatResponseFull) responseParser, err error) {
+	if t.nameResolvers != nil && t.values.Has("text") {
+		text, err := t.nameResolvers.resolve(ctx, t.values.Get("text"))
+		if err != nil {
+			return nil, nil, err
+		}
+		t.values.Set("text", text)
+	}
 
 	switch t.mode {
 	case chatResponse:
@@ -345,10 +365,23 @@ func (t sendConfig) BuildRequestContext(ctx context.Context, token, channelID st
 			deleteOriginal:  t.deleteOriginal,
 		}.BuildRequestContext(ctx)
 	default:
+		if t.jsonRequests && t.supportsJSON() {
+			return jsonSender{endpoint: t.endpoint, token: token, values: t.values}.BuildRequestContext(ctx)
+		}
 		return formSender{endpoint: t.endpoint, values: t.values}.BuildRequestContext(ctx)
 	}
 }
 
+// supportsJSON reports whether the configured endpoint accepts JSON bodies.
+func (t sendConfig) supportsJSON() bool {
+	switch t.endpoint {
+	case t.apiurl + string(chatPostMessage), t.apiurl + string(chatUpdate), t.apiurl + string(chatScheduleMessage):
+		return true
+	default:
+		return false
+	}
+}
+
 type formSender struct {
 	endpoint string
 	values   url.Values
@@ -365,6 +398,66 @@ func (t formSender) BuildRequestContext(ctx context.Context) (*http.Request, fun
 	}, err
 }
 
+// jsonValueKeys are the message arguments holding JSON encoded values, which
+// are embedded as is in JSON request bodies.
+var jsonValueKeys = map[string]bool{
+	"attachments": true,
+	"blocks":      true,
+	"metadata":    true,
+	"file_ids":    true,
+}
+
+// boolValueKeys are the message arguments holding booleans.
+var boolValueKeys = map[string]bool{
+	"as_user":         true,
+	"link_names":      true,
+	"mrkdwn":          true,
+	"reply_broadcast": true,
+	"unfurl_links":    true,
+	"unfurl_media":    true,
+}
+
+type jsonSender struct {
+	endpoint string
+	token    string
+	values   url.Values
+}
+
+func (t jsonSender) BuildRequest() (*http.Request, func(*chatResponseFull) responseParser, error) {
+	return t.BuildRequestContext(context.Background())
+}
+
+func (t jsonSender) BuildRequestContext(ctx context.Context) (*http.Request, func(*chatResponseFull) responseParser, error) {
+	body := make(map[string]interface{}, len(t.values))
+	for key := range t.values {
+		value := t.values.Get(key)
+		switch {
+		case key == "token":
+			continue
+		case jsonValueKeys[key]:
+			body[key] = json.RawMessage(value)
+		case boolValueKeys[key]:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, nil, err
+			}
+			body[key] = b
+		default:
+			body[key] = value
+		}
+	}
+
+	req, err := jsonReq(ctx, t.endpoint, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+t.token)
+
+	return req, func(resp *chatResponseFull) responseParser {
+		return newJSONParser(resp)
+	}, nil
+}
+
 type responseURLSender struct {
 	endpoint        string
 	values          url.Values
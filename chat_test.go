@@ -425,3 +425,99 @@ func TestUpdateMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestSendMessageJSONRequests(t *testing.T) {
+	type messageTest struct {
+		endpoint string
+		send     func(api *Client) error
+		expected string
+	}
+	tests := map[string]messageTest{
+		"post message": {
+			endpoint: "/chat.postMessage",
+			send: func(api *Client) error {
+				_, _, err := api.PostMessage("CXXX",
+					MsgOptionText("hello", false),
+					MsgOptionBlocks(NewDividerBlock()),
+					MsgOptionDisableLinkUnfurl(),
+					MsgOptionTS("1234567890.123456"),
+				)
+				return err
+			},
+			expected: `{"channel":"CXXX","text":"hello","blocks":[{"type":"divider"}],"unfurl_links":false,"thread_ts":"1234567890.123456"}`,
+		},
+		"update message": {
+			endpoint: "/chat.update",
+			send: func(api *Client) error {
+				_, _, _, err := api.UpdateMessage("CXXX", "1234567890.123456", MsgOptionFileIDs([]string{"F123"}))
+				return err
+			},
+			expected: `{"channel":"CXXX","ts":"1234567890.123456","file_ids":["F123"]}`,
+		},
+		"schedule message": {
+			endpoint: "/chat.scheduleMessage",
+			send: func(api *Client) error {
+				_, _, err := api.ScheduleMessage("CXXX", "1700000000", MsgOptionAsUser(true))
+				return err
+			},
+			expected: `{"channel":"CXXX","post_at":"1700000000","as_user":true}`,
+		},
+	}
+
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"), OptionJSONRequests())
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			http.DefaultServeMux = new(http.ServeMux)
+			http.HandleFunc(test.endpoint, func(rw http.ResponseWriter, r *http.Request) {
+				if got, want := r.Header.Get("Content-Type"), "application/json; charset=utf-8"; got != want {
+					t.Errorf("request uses unexpected content type: got %s, want %s", got, want)
+				}
+				if got, want := r.Header.Get("Authorization"), "Bearer "+validToken; got != want {
+					t.Errorf("request uses unexpected authorization: got %s, want %s", got, want)
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+
+				var actual, expected map[string]interface{}
+				if err := json.Unmarshal(body, &actual); err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				json.Unmarshal([]byte(test.expected), &expected)
+				if !reflect.DeepEqual(actual, expected) {
+					t.Errorf("\nexpected: %s\n  actual: %s", test.expected, body)
+				}
+
+				rw.Header().Set("Content-Type", "application/json")
+				rw.Write([]byte(`{"ok":true,"channel":"CXXX","ts":"1234567890.123456"}`))
+			})
+
+			if err := test.send(api); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSendMessageJSONRequestsFallsBackToForm(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/chat.delete", func(rw http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Content-Type"), "application/x-www-form-urlencoded"; got != want {
+			t.Errorf("request uses unexpected content type: got %s, want %s", got, want)
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":true}`))
+	})
+	once.Do(startServer)
+	api := New(validToken, OptionAPIURL("http://"+serverAddr+"/"), OptionJSONRequests())
+
+	if _, _, err := api.DeleteMessage("CXXX", "1234567890.123456"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -109,7 +109,8 @@ type GetConversationsForUserParameters struct {
 }
 
 type responseMetaData struct {
-	NextCursor string `json:"next_cursor"`
+	NextCursor string   `json:"next_cursor"`
+	Warnings   []string `json:"warnings"`
 }
 
 // GetUsersInConversation returns the list of users in a conversation.
@@ -121,6 +122,20 @@ func (api *Client) GetUsersInConversation(params *GetUsersInConversationParamete
 // GetUsersInConversationContext returns the list of users in a conversation with a custom context.
 // Slack API docs: https://api.slack.com/methods/conversations.members
 func (api *Client) GetUsersInConversationContext(ctx context.Context, params *GetUsersInConversationParameters) ([]string, string, error) {
+	members, page, err := api.GetUsersInConversationPageContext(ctx, params)
+	return members, page.NextCursor, err
+}
+
+// GetUsersInConversationPage returns a page of the users in a conversation.
+// For more details, see GetUsersInConversationPageContext documentation.
+func (api *Client) GetUsersInConversationPage(params *GetUsersInConversationParameters) ([]string, PageInfo, error) {
+	return api.GetUsersInConversationPageContext(context.Background(), params)
+}
+
+// GetUsersInConversationPageContext returns a page of the users in a conversation with a custom
+// context, along with its PageInfo, which reports whether Slack truncated the page.
+// Slack API docs: https://api.slack.com/methods/conversations.members
+func (api *Client) GetUsersInConversationPageContext(ctx context.Context, params *GetUsersInConversationParameters) ([]string, PageInfo, error) {
 	values := url.Values{
 		"token":   {api.token},
 		"channel": {params.ChannelID},
@@ -139,14 +154,17 @@ func (api *Client) GetUsersInConversationContext(ctx context.Context, params *Ge
 
 	err := api.postMethod(ctx, "conversations.members", values, &response)
 	if err != nil {
-		return nil, "", err
+		return nil, PageInfo{}, err
 	}
 
 	if err := response.Err(); err != nil {
-		return nil, "", err
+		return nil, PageInfo{}, err
 	}
 
-	return response.Members, response.ResponseMetaData.NextCursor, nil
+	page := newPageInfo(params.Limit, maxConversationsPageLimit, len(response.Members), response.ResponseMetaData.NextCursor, false, pageWarnings(response.Warning, response.ResponseMetaData.Warnings))
+	api.debugPageInfo("conversations.members", page)
+
+	return response.Members, page, nil
 }
 
 // GetConversationsForUser returns the list conversations for a given user.
@@ -725,6 +743,7 @@ func newConversationPagination(c *Client, options ...GetConversationsOption) (cp
 // ConversationPagination allows for paginating over the conversations
 type ConversationPagination struct {
 	Conversations   []Channel
+	PageInfo        PageInfo
 	limit           int
 	excludeArchived bool
 	types           []string
@@ -787,6 +806,8 @@ func (t ConversationPagination) Next(ctx context.Context) (_ ConversationPaginat
 	t.c.Debugf("GetAllConversationsContext: got %d conversations; cursor %s", len(response.Channels), response.ResponseMetaData.NextCursor)
 	t.Conversations = response.Channels
 	t.previousResp = &ResponseMetadata{Cursor: response.ResponseMetaData.NextCursor}
+	t.PageInfo = newPageInfo(t.limit, maxConversationsPageLimit, len(response.Channels), response.ResponseMetaData.NextCursor, false, pageWarnings(response.Warning, response.ResponseMetaData.Warnings))
+	t.c.debugPageInfo("conversations.list", t.PageInfo)
 
 	return t, nil
 }
@@ -956,6 +977,9 @@ type GetConversationHistoryResponse struct {
 		NextCursor string `json:"next_cursor"`
 	} `json:"response_metadata"`
 	Messages []Message `json:"messages"`
+	// Truncated reports that more messages are available but Slack capped the page to the
+	// largest limit conversations.history accepts, see PageInfo.
+	Truncated bool `json:"-"`
 }
 
 // GetConversationHistory joins an existing conversation.
@@ -998,6 +1022,10 @@ func (api *Client) GetConversationHistoryContext(ctx context.Context, params *Ge
 		return nil, err
 	}
 
+	page := newPageInfo(params.Limit, maxConversationsPageLimit, len(response.Messages), response.ResponseMetaData.NextCursor, response.HasMore, pageWarnings(response.Warning, nil))
+	response.Truncated = page.Truncated
+	api.debugPageInfo("conversations.history", page)
+
 	return &response, response.Err()
 }
 
@@ -0,0 +1,150 @@
+package slack
+
+import (
+	"context"
+	"errors"
+)
+
+// maxInviteUsers is the maximum number of users conversations.invite accepts in a single call.
+const maxInviteUsers = 1000
+
+// ConversationMembershipResult reports the outcome of adding or removing a single user
+// from a conversation with InviteUsersToConversationBulk or KickUsersFromConversationBulk.
+// These return a result for every user, in the order they were given, and only return an
+// error if the context is done before all users have been processed.
+type ConversationMembershipResult struct {
+	User string
+	Ok   bool
+	// Error is the error code returned by Slack for this user, e.g. already_in_channel,
+	// or the error message when the request itself failed.
+	Error string
+}
+
+// InviteUsersToConversationBulk invites users to a conversation in batches.
+// For more details, see InviteUsersToConversationBulkContext documentation.
+func (api *Client) InviteUsersToConversationBulk(channelID string, users ...string) ([]ConversationMembershipResult, error) {
+	return api.InviteUsersToConversationBulkContext(context.Background(), channelID, users...)
+}
+
+// InviteUsersToConversationBulkContext invites any number of users to a conversation with a
+// custom context, splitting them in batches accepted by conversations.invite. When Slack rejects
+// some users of a batch (e.g. already_in_channel or cant_invite_self), the remaining users are
+// invited again, and rate limited calls are retried once Slack allows it.
+func (api *Client) InviteUsersToConversationBulkContext(ctx context.Context, channelID string, users ...string) ([]ConversationMembershipResult, error) {
+	outcome := make(map[string]ConversationMembershipResult, len(users))
+
+	for start := 0; start < len(users); start += maxInviteUsers {
+		end := start + maxInviteUsers
+		if end > len(users) {
+			end = len(users)
+		}
+
+		if err := api.inviteBatch(ctx, channelID, users[start:end], outcome); err != nil {
+			return collectMembershipResults(users, outcome), err
+		}
+	}
+
+	return collectMembershipResults(users, outcome), nil
+}
+
+func (api *Client) inviteBatch(ctx context.Context, channelID string, batch []string, outcome map[string]ConversationMembershipResult) error {
+	for len(batch) > 0 {
+		err := retryRateLimited(ctx, func() error {
+			_, err := api.InviteUsersToConversationContext(ctx, channelID, batch...)
+			return err
+		})
+		if err == nil {
+			for _, user := range batch {
+				outcome[user] = ConversationMembershipResult{User: user, Ok: true}
+			}
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var rejected map[string]string
+		var slackErr SlackErrorResponse
+		if errors.As(err, &slackErr) {
+			rejected = rejectedInvites(slackErr)
+		}
+
+		remaining := batch[:0:0]
+		for _, user := range batch {
+			if _, ok := rejected[user]; !ok {
+				remaining = append(remaining, user)
+			}
+		}
+
+		// Without per user errors about this batch, retrying would send the same request again.
+		if len(remaining) == len(batch) {
+			for _, user := range batch {
+				outcome[user] = ConversationMembershipResult{User: user, Error: err.Error()}
+			}
+			return nil
+		}
+
+		for _, user := range batch {
+			if code, ok := rejected[user]; ok {
+				outcome[user] = ConversationMembershipResult{User: user, Error: code}
+			}
+		}
+		batch = remaining
+	}
+
+	return nil
+}
+
+// rejectedInvites returns the per user error codes of a failed conversations.invite call.
+func rejectedInvites(err SlackErrorResponse) map[string]string {
+	rejected := make(map[string]string)
+	for _, e := range err.Errors {
+		if e.ConversationsInviteResponseError != nil && !e.ConversationsInviteResponseError.Ok {
+			rejected[e.ConversationsInviteResponseError.User] = e.ConversationsInviteResponseError.Error
+		}
+	}
+
+	return rejected
+}
+
+// KickUsersFromConversationBulk removes users from a conversation one by one.
+// For more details, see KickUsersFromConversationBulkContext documentation.
+func (api *Client) KickUsersFromConversationBulk(channelID string, users ...string) ([]ConversationMembershipResult, error) {
+	return api.KickUsersFromConversationBulkContext(context.Background(), channelID, users...)
+}
+
+// KickUsersFromConversationBulkContext removes any number of users from a conversation with a
+// custom context. conversations.kick only accepts a single user, so the users are removed one
+// after the other, waiting whenever Slack rate limits the calls. Failing to remove a user, e.g.
+// with not_in_channel, does not prevent the others from being removed.
+func (api *Client) KickUsersFromConversationBulkContext(ctx context.Context, channelID string, users ...string) ([]ConversationMembershipResult, error) {
+	outcome := make(map[string]ConversationMembershipResult, len(users))
+
+	for _, user := range users {
+		err := retryRateLimited(ctx, func() error {
+			return api.KickUserFromConversationContext(ctx, channelID, user)
+		})
+		if ctx.Err() != nil {
+			return collectMembershipResults(users, outcome), ctx.Err()
+		}
+
+		if err != nil {
+			outcome[user] = ConversationMembershipResult{User: user, Error: err.Error()}
+		} else {
+			outcome[user] = ConversationMembershipResult{User: user, Ok: true}
+		}
+	}
+
+	return collectMembershipResults(users, outcome), nil
+}
+
+func collectMembershipResults(users []string, outcome map[string]ConversationMembershipResult) []ConversationMembershipResult {
+	results := make([]ConversationMembershipResult, 0, len(outcome))
+	for _, user := range users {
+		if result, ok := outcome[user]; ok {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,131 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInviteUsersToConversationBulk(t *testing.T) {
+	var calls int32
+	var requests [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		users := strings.Split(r.FormValue("users"), ",")
+		requests = append(requests, users)
+
+		rw.Header().Set("Content-Type", "application/json")
+		var errs []ConversationsInviteResponseError
+		for _, user := range users {
+			switch user {
+			case "U_MEMBER":
+				errs = append(errs, ConversationsInviteResponseError{User: user, Error: "already_in_channel"})
+			case "U_SELF":
+				errs = append(errs, ConversationsInviteResponseError{User: user, Error: "cant_invite_self"})
+			}
+		}
+		if len(errs) > 0 {
+			response, _ := json.Marshal(map[string]interface{}{"ok": false, "error": errs[0].Error, "errors": errs})
+			rw.Write(response)
+			return
+		}
+		rw.Write([]byte(`{"ok":true,"channel":{"id":"CXXXXXXXX"}}`))
+	}))
+	defer srv.Close()
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+
+	results, err := api.InviteUsersToConversationBulkContext(context.Background(), "CXXXXXXXX", "U1", "U_MEMBER", "U2", "U_SELF")
+	require.NoError(t, err)
+
+	assert.Equal(t, []ConversationMembershipResult{
+		{User: "U1", Ok: true},
+		{User: "U_MEMBER", Error: "already_in_channel"},
+		{User: "U2", Ok: true},
+		{User: "U_SELF", Error: "cant_invite_self"},
+	}, results)
+	assert.Equal(t, [][]string{{"U1", "U_MEMBER", "U2", "U_SELF"}, {"U1", "U2"}}, requests)
+}
+
+func TestInviteUsersToConversationBulkChunks(t *testing.T) {
+	var batches []int
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		batches = append(batches, len(strings.Split(r.FormValue("users"), ",")))
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":false,"error":"channel_not_found"}`))
+	}))
+	defer srv.Close()
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+
+	users := make([]string, maxInviteUsers+5)
+	for i := range users {
+		users[i] = fmt.Sprintf("U%d", i)
+	}
+
+	results, err := api.InviteUsersToConversationBulk("CXXXXXXXX", users...)
+	require.NoError(t, err)
+	assert.Equal(t, []int{maxInviteUsers, 5}, batches)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "channel_not_found", results[0].Error)
+}
+
+func TestInviteUsersToConversationBulkUnrelatedErrors(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":false,"error":"user_not_found","errors":[{"user":"U_OTHER","ok":false,"error":"user_not_found"}]}`))
+	}))
+	defer srv.Close()
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+
+	results, err := api.InviteUsersToConversationBulk("CXXXXXXXX", "U1", "U2")
+	require.NoError(t, err)
+	assert.Equal(t, []ConversationMembershipResult{
+		{User: "U1", Error: "user_not_found"},
+		{User: "U2", Error: "user_not_found"},
+	}, results)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestKickUsersFromConversationBulk(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 2 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		if r.FormValue("user") == "U_GONE" {
+			rw.Write([]byte(`{"ok":false,"error":"not_in_channel"}`))
+			return
+		}
+		rw.Write([]byte(`{"ok":true,"errors":{}}`))
+	}))
+	defer srv.Close()
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+
+	results, err := api.KickUsersFromConversationBulk("CXXXXXXXX", "U1", "U_GONE", "U2")
+	require.NoError(t, err)
+	assert.Equal(t, []ConversationMembershipResult{
+		{User: "U1", Ok: true},
+		{User: "U_GONE", Error: "not_in_channel"},
+		{User: "U2", Ok: true},
+	}, results)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
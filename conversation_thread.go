@@ -0,0 +1,149 @@
+package slack
+
+import (
+	"context"
+	"regexp"
+	"sort"
+)
+
+// ConversationThread is a complete thread of a conversation.
+type ConversationThread struct {
+	Parent Message
+	// Replies are the replies of the thread, oldest first.
+	Replies []Message
+	// UserNames maps the IDs of the authors and mentioned users of the thread to their names.
+	// It is only set when a UserNameResolver is given.
+	UserNames map[string]string
+}
+
+// Messages returns the parent followed by the replies of the thread.
+func (t *ConversationThread) Messages() []Message {
+	return append([]Message{t.Parent}, t.Replies...)
+}
+
+// GetFullConversationRepliesOption options for the GetFullConversationReplies method call.
+type GetFullConversationRepliesOption func(*fullConversationReplies)
+
+type fullConversationReplies struct {
+	resolveUser        UserNameResolver
+	includeAllMetadata bool
+}
+
+// GetFullConversationRepliesOptionResolveUser resolves the IDs of the authors and mentioned users
+// of the thread to names, which are returned in ConversationThread.UserNames.
+func GetFullConversationRepliesOptionResolveUser(resolver UserNameResolver) GetFullConversationRepliesOption {
+	return func(p *fullConversationReplies) {
+		p.resolveUser = resolver
+	}
+}
+
+// GetFullConversationRepliesOptionIncludeAllMetadata includes the metadata of the messages.
+func GetFullConversationRepliesOptionIncludeAllMetadata(include bool) GetFullConversationRepliesOption {
+	return func(p *fullConversationReplies) {
+		p.includeAllMetadata = include
+	}
+}
+
+// GetFullConversationReplies retrieves a complete thread.
+// For more details, see GetFullConversationRepliesContext documentation.
+func (api *Client) GetFullConversationReplies(channelID, threadTS string, options ...GetFullConversationRepliesOption) (*ConversationThread, error) {
+	return api.GetFullConversationRepliesContext(context.Background(), channelID, threadTS, options...)
+}
+
+// GetFullConversationRepliesContext retrieves a complete thread with a custom context, walking all
+// the pages of conversations.replies and waiting whenever Slack rate limits the calls. threadTS may
+// be the timestamp of the parent message or of any reply of the thread.
+func (api *Client) GetFullConversationRepliesContext(ctx context.Context, channelID, threadTS string, options ...GetFullConversationRepliesOption) (*ConversationThread, error) {
+	var config fullConversationReplies
+	for _, opt := range options {
+		opt(&config)
+	}
+
+	params := &GetConversationRepliesParameters{
+		ChannelID:          channelID,
+		Timestamp:          threadTS,
+		Limit:              200,
+		IncludeAllMetadata: config.includeAllMetadata,
+	}
+
+	msgs, err := api.collectConversationReplies(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Given the timestamp of a reply, conversations.replies only returns that reply.
+	if len(msgs) > 0 && msgs[0].ThreadTimestamp != "" && msgs[0].ThreadTimestamp != msgs[0].Timestamp {
+		params.Timestamp = msgs[0].ThreadTimestamp
+		if msgs, err = api.collectConversationReplies(ctx, params); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(msgs) == 0 {
+		return nil, SlackErrorResponse{Err: "thread_not_found"}
+	}
+
+	thread := &ConversationThread{
+		Parent:  msgs[0],
+		Replies: msgs[1:],
+	}
+
+	if config.resolveUser != nil {
+		names := userNameCache{resolve: config.resolveUser, cache: make(map[string]string)}
+		for _, msg := range msgs {
+			if msg.User != "" {
+				names.user(ctx, msg.User)
+			}
+			for _, m := range userMentionPattern.FindAllStringSubmatch(msg.Text, -1) {
+				names.user(ctx, m[1])
+			}
+		}
+		thread.UserNames = names.cache
+	}
+
+	return thread, nil
+}
+
+var userMentionPattern = regexp.MustCompile(`<@([UW][A-Z0-9]+)(?:\|[^>]*)?>`)
+
+// collectConversationReplies walks all the pages of conversations.replies and returns the
+// messages of the thread oldest first, without the copies of the parent included in every page.
+func (api *Client) collectConversationReplies(ctx context.Context, params *GetConversationRepliesParameters) ([]Message, error) {
+	p := *params
+	seen := make(map[string]struct{})
+
+	var msgs []Message
+	for {
+		var (
+			page    []Message
+			hasMore bool
+			cursor  string
+		)
+		err := retryRateLimited(ctx, func() (err error) {
+			page, hasMore, cursor, err = api.GetConversationRepliesContext(ctx, &p)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, msg := range page {
+			if _, ok := seen[msg.Timestamp]; ok {
+				continue
+			}
+			seen[msg.Timestamp] = struct{}{}
+			msgs = append(msgs, msg)
+		}
+
+		if !hasMore || cursor == "" {
+			break
+		}
+		p.Cursor = cursor
+	}
+
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return timestampToTime(msgs[i].Timestamp).Before(timestampToTime(msgs[j].Timestamp))
+	})
+
+	return msgs, nil
+}
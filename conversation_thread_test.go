@@ -0,0 +1,64 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFullConversationReplies(t *testing.T) {
+	var requests []string
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/conversations.replies", func(rw http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.FormValue("ts")+"/"+r.FormValue("cursor"))
+		rw.Header().Set("Content-Type", "application/json")
+
+		switch r.FormValue("ts") + "/" + r.FormValue("cursor") {
+		case "1700000002.000000/":
+			// Asking for a reply only returns the reply.
+			rw.Write([]byte(`{"ok":true,"messages":[
+				{"type":"message","user":"U2","text":"second","ts":"1700000002.000000","thread_ts":"1700000000.000000"}
+			]}`))
+		case "1700000000.000000/":
+			rw.Write([]byte(`{"ok":true,"has_more":true,"response_metadata":{"next_cursor":"page2"},"messages":[
+				{"type":"message","user":"U1","text":"parent","ts":"1700000000.000000","thread_ts":"1700000000.000000","reply_count":3},
+				{"type":"message","user":"U2","text":"second","ts":"1700000002.000000","thread_ts":"1700000000.000000"},
+				{"type":"message","user":"U1","text":"first <@U3>","ts":"1700000001.000000","thread_ts":"1700000000.000000"}
+			]}`))
+		case "1700000000.000000/page2":
+			rw.Write([]byte(`{"ok":true,"has_more":false,"messages":[
+				{"type":"message","user":"U1","text":"parent","ts":"1700000000.000000","thread_ts":"1700000000.000000","reply_count":3},
+				{"type":"message","user":"U2","text":"third","ts":"1700000003.000000","thread_ts":"1700000000.000000"}
+			]}`))
+		default:
+			rw.Write([]byte(`{"ok":false,"error":"thread_not_found"}`))
+		}
+	})
+	once.Do(startServer)
+	api := New("testing-token", OptionAPIURL("http://"+serverAddr+"/"))
+
+	resolver := func(ctx context.Context, userID string) (string, error) {
+		return map[string]string{"U1": "alice", "U2": "bob", "U3": "carol"}[userID], nil
+	}
+
+	thread, err := api.GetFullConversationRepliesContext(context.Background(), "C1", "1700000002.000000",
+		GetFullConversationRepliesOptionResolveUser(resolver))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1700000002.000000/", "1700000000.000000/", "1700000000.000000/page2"}, requests)
+	assert.Equal(t, "parent", thread.Parent.Text)
+
+	var texts []string
+	for _, msg := range thread.Replies {
+		texts = append(texts, msg.Text)
+	}
+	assert.Equal(t, []string{"first <@U3>", "second", "third"}, texts)
+	assert.Len(t, thread.Messages(), 4)
+	assert.Equal(t, map[string]string{"U1": "alice", "U2": "bob", "U3": "carol"}, thread.UserNames)
+
+	_, err = api.GetFullConversationReplies("C1", "1600000000.000000")
+	assert.EqualError(t, err, "thread_not_found")
+}
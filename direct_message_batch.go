@@ -0,0 +1,431 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/slack-go/slack/slackutilsx"
+)
+
+// DirectMessageTemplate is a message rendered for every recipient of a direct message batch,
+// with the text/template syntax and a DirectMessageData.
+type DirectMessageTemplate struct {
+	text   *template.Template
+	blocks interface{}
+}
+
+var directMessageTemplateFuncs = template.FuncMap{
+	"escape": slackutilsx.EscapeMessage,
+}
+
+// NewDirectMessageTemplate parses the templates of a direct message: text, its mrkdwn text, and
+// blocks, a JSON array of blocks, or an object holding them as exported by Block Kit Builder.
+// Either may be empty. The strings of the blocks are templates too, which are rendered before
+// decoding the blocks, so that the values they insert need no JSON escaping.
+//
+// The name and the string values of the recipient are escaped, so that they are not interpreted
+// as mrkdwn. Their raw versions are available as .RawName and .RawValues, e.g. for plain_text
+// fields, and the escape function escapes any other value, e.g. {{escape .User.Profile.Title}}.
+func NewDirectMessageTemplate(text, blocks string) (*DirectMessageTemplate, error) {
+	t := &DirectMessageTemplate{}
+
+	var err error
+	if t.text, err = template.New("text").Funcs(directMessageTemplateFuncs).Parse(text); err != nil {
+		return nil, err
+	}
+
+	if blocks == "" {
+		return t, nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(blocks))
+	decoder.UseNumber()
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("invalid blocks: %w", err)
+	}
+	if object, ok := tree.(map[string]interface{}); ok {
+		tree = object["blocks"]
+	}
+	if _, ok := tree.([]interface{}); !ok {
+		return nil, fmt.Errorf("invalid blocks: expected an array of blocks")
+	}
+
+	if t.blocks, err = parseBlocksTemplate(tree); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// parseBlocksTemplate replaces the strings of a decoded JSON value holding template actions with
+// templates.
+func parseBlocksTemplate(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			parsed, err := parseBlocksTemplate(v)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = parsed
+		}
+	case []interface{}:
+		for i, v := range value {
+			parsed, err := parseBlocksTemplate(v)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = parsed
+		}
+	case string:
+		if strings.Contains(value, "{{") {
+			return template.New("blocks").Funcs(directMessageTemplateFuncs).Parse(value)
+		}
+	}
+
+	return value, nil
+}
+
+// renderBlocksTemplate returns a copy of value with its templates executed.
+func renderBlocksTemplate(value interface{}, data DirectMessageData) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(value))
+		for key, v := range value {
+			r, err := renderBlocksTemplate(v, data)
+			if err != nil {
+				return nil, err
+			}
+			rendered[key] = r
+		}
+		return rendered, nil
+	case []interface{}:
+		rendered := make([]interface{}, len(value))
+		for i, v := range value {
+			r, err := renderBlocksTemplate(v, data)
+			if err != nil {
+				return nil, err
+			}
+			rendered[i] = r
+		}
+		return rendered, nil
+	case *template.Template:
+		var b strings.Builder
+		if err := value.Execute(&b, data); err != nil {
+			return nil, err
+		}
+		return b.String(), nil
+	default:
+		return value, nil
+	}
+}
+
+// Render returns the options setting the text and blocks of the message of a recipient.
+func (t *DirectMessageTemplate) Render(data DirectMessageData) ([]MsgOption, error) {
+	var text bytes.Buffer
+	if err := t.text.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	options := []MsgOption{MsgOptionText(text.String(), false)}
+
+	if t.blocks != nil {
+		tree, err := renderBlocksTemplate(t.blocks, data)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := json.Marshal(tree)
+		if err != nil {
+			return nil, err
+		}
+		var blocks Blocks
+		if err := json.Unmarshal(raw, &blocks); err != nil {
+			return nil, fmt.Errorf("invalid blocks: %w", err)
+		}
+		options = append(options, MsgOptionBlocks(blocks.BlockSet...))
+	}
+
+	return options, nil
+}
+
+// DirectMessageRecipient is a user receiving a direct message batch.
+type DirectMessageRecipient struct {
+	User string
+	// Values are custom values of the recipient, available to the templates as .Values.
+	Values map[string]interface{}
+}
+
+// DirectMessageData is the data the templates of a direct message batch are rendered with for a
+// recipient.
+type DirectMessageData struct {
+	// User is the recipient, as returned by users.info.
+	User User
+	// Name is the escaped display name of the recipient, or their real name or username if
+	// they have no display name.
+	Name string
+	// RawName is Name before escaping.
+	RawName string
+	// Mention is the mention of the recipient, e.g. <@U123>.
+	Mention string
+	// Values are the values of the recipient, with their strings escaped.
+	Values map[string]interface{}
+	// RawValues are the values of the recipient as given.
+	RawValues map[string]interface{}
+}
+
+func newDirectMessageData(user User, values map[string]interface{}) DirectMessageData {
+	name := user.Profile.DisplayName
+	if name == "" {
+		name = user.RealName
+	}
+	if name == "" {
+		name = user.Name
+	}
+
+	escaped := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if s, ok := value.(string); ok {
+			value = slackutilsx.EscapeMessage(s)
+		}
+		escaped[key] = value
+	}
+
+	return DirectMessageData{
+		User:      user,
+		Name:      slackutilsx.EscapeMessage(name),
+		RawName:   name,
+		Mention:   slackutilsx.Mention(user.ID),
+		Values:    escaped,
+		RawValues: values,
+	}
+}
+
+// Date returns a <!date> token, which clients display in the locale and time zone of the reader,
+// following format, e.g. "{date_long} at {time}". Clients not supporting it display t in the
+// time zone of the recipient instead.
+func (d DirectMessageData) Date(t time.Time, format string) string {
+	if d.User.TZ != "" {
+		if location, err := time.LoadLocation(d.User.TZ); err == nil {
+			t = t.In(location)
+		}
+	}
+
+	return fmt.Sprintf("<!date^%d^%s|%s>", t.Unix(), format, t.Format("Mon, Jan 2, 2006 at 3:04 PM MST"))
+}
+
+// DirectMessageResult reports the outcome of a direct message batch for a single recipient.
+// SendDirectMessageBatch reports every recipient it reached, in the order of the recipients,
+// and stops with the context error if the context is done first.
+type DirectMessageResult struct {
+	User string
+	// Channel and Timestamp identify the sent message.
+	Channel   string
+	Timestamp string
+	Ok        bool
+	// Error is the error code returned by Slack for this recipient, e.g. user_not_found, or the
+	// error message when rendering the template or sending the request failed.
+	Error string
+}
+
+type directMessageBatchConfig struct {
+	batchSize  int
+	interval   time.Duration
+	msgOptions []MsgOption
+	onResult   func(DirectMessageResult)
+}
+
+// DirectMessageBatchOption configures a direct message batch.
+type DirectMessageBatchOption func(*directMessageBatchConfig)
+
+// DirectMessageBatchOptionSize sets how many recipients are looked up and have their DM opened
+// before their messages are sent, 30 by default.
+func DirectMessageBatchOptionSize(n int) DirectMessageBatchOption {
+	return func(c *directMessageBatchConfig) {
+		if n > 0 {
+			c.batchSize = n
+		}
+	}
+}
+
+// DirectMessageBatchOptionInterval sets the delay between two messages, one second by default.
+func DirectMessageBatchOptionInterval(d time.Duration) DirectMessageBatchOption {
+	return func(c *directMessageBatchConfig) {
+		c.interval = d
+	}
+}
+
+// DirectMessageBatchOptionMsgOptions adds options to every message, e.g. MsgOptionUsername.
+func DirectMessageBatchOptionMsgOptions(options ...MsgOption) DirectMessageBatchOption {
+	return func(c *directMessageBatchConfig) {
+		c.msgOptions = append(c.msgOptions, options...)
+	}
+}
+
+// DirectMessageBatchOptionOnResult sets a function called with the result of every recipient as soon as
+// it is known, e.g. to report progress.
+func DirectMessageBatchOptionOnResult(fn func(DirectMessageResult)) DirectMessageBatchOption {
+	return func(c *directMessageBatchConfig) {
+		c.onResult = fn
+	}
+}
+
+// SendDirectMessageBatch sends a direct message rendered from tmpl to every recipient.
+// For more details, see SendDirectMessageBatchContext documentation.
+func (api *Client) SendDirectMessageBatch(tmpl *DirectMessageTemplate, recipients []DirectMessageRecipient, options ...DirectMessageBatchOption) ([]DirectMessageResult, error) {
+	return api.SendDirectMessageBatchContext(context.Background(), tmpl, recipients, options...)
+}
+
+// SendDirectMessageBatchContext sends a direct message rendered from tmpl to every recipient with a custom
+// context. Recipients are processed in batches: their profiles are fetched with users.info and
+// their DMs opened, then their messages are sent one after the other, paced by the interval.
+// Rate limited calls are retried once Slack allows it, and failing to reach a recipient does not
+// prevent the others from receiving their message.
+func (api *Client) SendDirectMessageBatchContext(ctx context.Context, tmpl *DirectMessageTemplate, recipients []DirectMessageRecipient, options ...DirectMessageBatchOption) ([]DirectMessageResult, error) {
+	config := directMessageBatchConfig{batchSize: 30, interval: time.Second}
+	for _, opt := range options {
+		opt(&config)
+	}
+
+	results := make([]DirectMessageResult, 0, len(recipients))
+	report := func(result DirectMessageResult) {
+		results = append(results, result)
+		if config.onResult != nil {
+			config.onResult(result)
+		}
+	}
+
+	sent := 0
+	for start := 0; start < len(recipients); start += config.batchSize {
+		end := start + config.batchSize
+		if end > len(recipients) {
+			end = len(recipients)
+		}
+		batch := recipients[start:end]
+
+		users, failures, err := api.directMessageUsers(ctx, batch)
+		if err != nil {
+			return results, err
+		}
+
+		channels := make(map[string]string, len(batch))
+		for _, recipient := range batch {
+			if _, ok := users[recipient.User]; !ok {
+				continue
+			}
+
+			var channel *Channel
+			err := retryRateLimited(ctx, func() (err error) {
+				channel, _, _, err = api.OpenConversationContext(ctx, &OpenConversationParameters{Users: []string{recipient.User}})
+				return err
+			})
+			if ctx.Err() != nil {
+				return results, ctx.Err()
+			}
+			if err != nil {
+				failures[recipient.User] = err.Error()
+				continue
+			}
+			channels[recipient.User] = channel.ID
+		}
+
+		for _, recipient := range batch {
+			result := DirectMessageResult{User: recipient.User, Channel: channels[recipient.User]}
+
+			user, ok := users[recipient.User]
+			switch {
+			case failures[recipient.User] != "":
+				result.Error = failures[recipient.User]
+			case !ok:
+				result.Error = "user_not_found"
+			default:
+				if sent > 0 && config.interval > 0 {
+					if err := sleepContext(ctx, config.interval); err != nil {
+						return results, err
+					}
+				}
+				sent++
+
+				result.Timestamp, err = api.sendDirectMessage(ctx, tmpl, result.Channel, newDirectMessageData(user, recipient.Values), config.msgOptions)
+				if ctx.Err() != nil {
+					return results, ctx.Err()
+				}
+				if err != nil {
+					result.Error = err.Error()
+				} else {
+					result.Ok = true
+				}
+			}
+
+			report(result)
+		}
+	}
+
+	return results, nil
+}
+
+// directMessageUsers fetches the recipients of a batch, falling back to fetching them one by one
+// if Slack rejects the batch, e.g. because one of them does not exist. It returns the users found
+// and the errors of the recipients that could not be fetched.
+func (api *Client) directMessageUsers(ctx context.Context, batch []DirectMessageRecipient) (map[string]User, map[string]string, error) {
+	ids := make([]string, 0, len(batch))
+	for _, recipient := range batch {
+		ids = append(ids, recipient.User)
+	}
+
+	var users *[]User
+	err := retryRateLimited(ctx, func() (err error) {
+		users, err = api.GetUsersInfoContext(ctx, ids...)
+		return err
+	})
+	if ctx.Err() != nil {
+		return nil, nil, ctx.Err()
+	}
+
+	found := make(map[string]User, len(batch))
+	failures := make(map[string]string)
+	if err == nil {
+		for _, user := range *users {
+			found[user.ID] = user
+		}
+		return found, failures, nil
+	}
+
+	for _, id := range ids {
+		var user *User
+		err := retryRateLimited(ctx, func() (err error) {
+			user, err = api.GetUserInfoContext(ctx, id)
+			return err
+		})
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		if err != nil {
+			failures[id] = err.Error()
+			continue
+		}
+		found[user.ID] = *user
+	}
+
+	return found, failures, nil
+}
+
+func (api *Client) sendDirectMessage(ctx context.Context, tmpl *DirectMessageTemplate, channel string, data DirectMessageData, extra []MsgOption) (string, error) {
+	options, err := tmpl.Render(data)
+	if err != nil {
+		return "", err
+	}
+	options = append(append([]MsgOption(nil), extra...), options...)
+
+	var timestamp string
+	err = retryRateLimited(ctx, func() (err error) {
+		_, timestamp, err = api.PostMessageContext(ctx, channel, options...)
+		return err
+	})
+
+	return timestamp, err
+}
@@ -0,0 +1,141 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectMessageTemplateRender(t *testing.T) {
+	tmpl, err := NewDirectMessageTemplate("Welcome {{.Name}}!", `{"blocks": [
+		{"type": "section", "text": {"type": "mrkdwn", "text": "Hi {{.Mention}}, the kickoff is {{.Date .Values.kickoff \"{date_long} at {time}\"}}. \"{{.Values.team}}\""}},
+		{"type": "header", "text": {"type": "plain_text", "text": "{{.RawName}} & {{.RawValues.team}}"}},
+		{"type": "divider"}
+	]}`)
+	require.NoError(t, err)
+
+	user := User{ID: "U1", Name: "ada", RealName: "Ada <Lovelace>", TZ: "Europe/London"}
+	kickoff := time.Date(2026, time.January, 5, 9, 30, 0, 0, time.UTC)
+	options, err := tmpl.Render(newDirectMessageData(user, map[string]interface{}{"kickoff": kickoff, "team": "R&D"}))
+	require.NoError(t, err)
+
+	config, err := applyMsgOptions("", "C1", "", options...)
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome Ada &lt;Lovelace&gt;!", config.values.Get("text"))
+	require.Len(t, config.blocks.BlockSet, 3)
+	section := config.blocks.BlockSet[0].(*SectionBlock)
+	assert.Equal(t, `Hi <@U1>, the kickoff is <!date^1767605400^{date_long} at {time}|Mon, Jan 5, 2026 at 9:30 AM GMT>. "R&amp;D"`, section.Text.Text)
+	header := config.blocks.BlockSet[1].(*HeaderBlock)
+	assert.Equal(t, "Ada <Lovelace> & R&D", header.Text.Text)
+
+	_, err = NewDirectMessageTemplate("", `{"type": "divider"}`)
+	assert.Error(t, err)
+	_, err = NewDirectMessageTemplate("", `[{"type": "section", "text": {"type": "mrkdwn", "text": "{{.Name"}}]`)
+	assert.Error(t, err)
+}
+
+func TestDirectMessageBatch(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls []string
+		posts = map[string]string{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, strings.TrimPrefix(r.URL.Path, "/"))
+
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users.info":
+			if strings.Contains(r.Form.Get("users")+r.Form.Get("user"), "U404") {
+				rw.Write([]byte(`{"ok":false,"error":"user_not_found"}`))
+				return
+			}
+			if id := r.Form.Get("user"); id != "" {
+				json.NewEncoder(rw).Encode(map[string]interface{}{"ok": true, "user": map[string]string{"id": id}})
+				return
+			}
+			var users []map[string]interface{}
+			for _, id := range strings.Split(r.Form.Get("users"), ",") {
+				users = append(users, map[string]interface{}{"id": id, "name": strings.ToLower(id), "profile": map[string]string{"display_name": "name-" + id}})
+			}
+			json.NewEncoder(rw).Encode(map[string]interface{}{"ok": true, "users": users})
+		case "/conversations.open":
+			if r.Form.Get("users") == "U3" {
+				rw.Write([]byte(`{"ok":false,"error":"cannot_dm_bot"}`))
+				return
+			}
+			json.NewEncoder(rw).Encode(map[string]interface{}{"ok": true, "channel": map[string]string{"id": "D" + r.Form.Get("users")}})
+		case "/chat.postMessage":
+			posts[r.Form.Get("channel")] = r.Form.Get("text")
+			json.NewEncoder(rw).Encode(map[string]interface{}{"ok": true, "channel": r.Form.Get("channel"), "ts": "1.0"})
+		}
+	}))
+	defer srv.Close()
+
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+	tmpl, err := NewDirectMessageTemplate("Hello {{.Name}}, {{.Values.greeting}}", "")
+	require.NoError(t, err)
+
+	var reported []string
+	results, err := api.SendDirectMessageBatchContext(context.Background(), tmpl, []DirectMessageRecipient{
+		{User: "U1", Values: map[string]interface{}{"greeting": "welcome"}},
+		{User: "U2", Values: map[string]interface{}{"greeting": "welcome back"}},
+		{User: "U3"},
+		{User: "U404"},
+	},
+		DirectMessageBatchOptionSize(2),
+		DirectMessageBatchOptionInterval(0),
+		DirectMessageBatchOptionOnResult(func(result DirectMessageResult) { reported = append(reported, result.User) }),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []DirectMessageResult{
+		{User: "U1", Channel: "DU1", Timestamp: "1.0", Ok: true},
+		{User: "U2", Channel: "DU2", Timestamp: "1.0", Ok: true},
+		{User: "U3", Error: "cannot_dm_bot"},
+		{User: "U404", Error: "user_not_found"},
+	}, results)
+	assert.Equal(t, []string{"U1", "U2", "U3", "U404"}, reported)
+	assert.Equal(t, map[string]string{"DU1": "Hello name-U1, welcome", "DU2": "Hello name-U2, welcome back"}, posts)
+	assert.Equal(t, []string{
+		"users.info", "conversations.open", "conversations.open", "chat.postMessage", "chat.postMessage",
+		"users.info", "users.info", "users.info", "conversations.open",
+	}, calls)
+}
+
+func TestSendDirectMessageBatchContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users.info":
+			json.NewEncoder(rw).Encode(map[string]interface{}{"ok": true, "users": []map[string]string{{"id": "U1"}, {"id": "U2"}}})
+		case "/conversations.open":
+			json.NewEncoder(rw).Encode(map[string]interface{}{"ok": true, "channel": map[string]string{"id": "D" + r.Form.Get("users")}})
+		case "/chat.postMessage":
+			json.NewEncoder(rw).Encode(map[string]interface{}{"ok": true, "channel": r.Form.Get("channel"), "ts": "1.0"})
+		}
+	}))
+	defer srv.Close()
+
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+	tmpl, err := NewDirectMessageTemplate("Hello", "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	results, err := api.SendDirectMessageBatchContext(ctx, tmpl, []DirectMessageRecipient{{User: "U1"}, {User: "U2"}}, DirectMessageBatchOptionInterval(time.Minute))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []DirectMessageResult{{User: "U1", Channel: "DU1", Timestamp: "1.0", Ok: true}}, results)
+}
@@ -13,6 +13,8 @@ const (
 	ErrInvalidConfiguration = errorsx.String("invalid configuration")
 	ErrMissingHeaders       = errorsx.String("missing headers")
 	ErrExpiredTimestamp     = errorsx.String("timestamp is too old")
+	ErrStateValueNotFound   = errorsx.String("view state value not found")
+	ErrStateValueType       = errorsx.String("view state value has an unexpected type")
 )
 
 // internal errors
@@ -0,0 +1,261 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UserNameResolver resolves a user ID to the name displayed in place of the ID.
+type UserNameResolver func(ctx context.Context, userID string) (string, error)
+
+// ConversationExportFormat is the output format of ExportConversation.
+type ConversationExportFormat string
+
+const (
+	ConversationExportMarkdown ConversationExportFormat = "markdown"
+	ConversationExportHTML     ConversationExportFormat = "html"
+)
+
+// ConversationExportParameters contains arguments for ExportConversation method calls.
+type ConversationExportParameters struct {
+	ChannelID string
+	// ThreadTS restricts the export to the thread of the given parent message.
+	ThreadTS string
+	Oldest   string
+	Latest   string
+	// Title is the heading of the document, the channel ID by default.
+	Title  string
+	Format ConversationExportFormat
+	// ResolveUser resolves the authors and the mentioned users to names. When it is nil, or
+	// fails, user IDs are kept as is.
+	ResolveUser UserNameResolver
+}
+
+// ExportConversation renders a conversation or a thread as a standalone document.
+// For more details, see ExportConversationContext documentation.
+func (api *Client) ExportConversation(w io.Writer, params ConversationExportParameters) error {
+	return api.ExportConversationContext(context.Background(), w, params)
+}
+
+// ExportConversationContext writes every message of a conversation, or of a thread when ThreadTS
+// is set, to w as a self-contained Markdown or HTML document with a custom context. Messages are
+// written oldest first, with their author, time, content rendered by a MessageRenderer and the
+// list of their files, which makes the output suitable for audit snapshots and postmortem
+// archives. Rate limited calls are retried once Slack allows it.
+func (api *Client) ExportConversationContext(ctx context.Context, w io.Writer, params ConversationExportParameters) error {
+	var render func(io.Writer, string, []exportedMessage) error
+	switch params.Format {
+	case ConversationExportMarkdown, "":
+		render = renderMarkdownExport
+	case ConversationExportHTML:
+		render = renderHTMLExport
+	default:
+		return fmt.Errorf("unsupported export format %q", params.Format)
+	}
+
+	var (
+		msgs []Message
+		err  error
+	)
+	if params.ThreadTS != "" {
+		msgs, err = api.collectConversationReplies(ctx, &GetConversationRepliesParameters{
+			ChannelID: params.ChannelID,
+			Timestamp: params.ThreadTS,
+			Oldest:    params.Oldest,
+			Latest:    params.Latest,
+			Limit:     200,
+		})
+	} else {
+		msgs, err = api.exportHistory(ctx, params)
+	}
+	if err != nil {
+		return err
+	}
+
+	names := userNameCache{resolve: params.ResolveUser, cache: make(map[string]string)}
+	renderer := MessageRenderer{
+		ResolveUser: func(ctx context.Context, userID string) (string, error) {
+			return names.user(ctx, userID), nil
+		},
+	}
+	if params.Format != ConversationExportHTML {
+		renderer.Format = MessageRenderMarkdown
+	}
+
+	exported := make([]exportedMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		exported = append(exported, exportedMessage{
+			Msg:    msg.Msg,
+			Author: names.author(ctx, msg.Msg),
+			Time:   timestampToTime(msg.Timestamp),
+			Text:   renderer.Render(ctx, msg.Msg),
+		})
+	}
+
+	title := params.Title
+	if title == "" {
+		title = params.ChannelID
+	}
+
+	return render(w, title, exported)
+}
+
+func (api *Client) exportHistory(ctx context.Context, params ConversationExportParameters) ([]Message, error) {
+	history := &GetConversationHistoryParameters{
+		ChannelID: params.ChannelID,
+		Oldest:    params.Oldest,
+		Latest:    params.Latest,
+		Limit:     200,
+	}
+
+	var msgs []Message
+	for {
+		var resp *GetConversationHistoryResponse
+		err := retryRateLimited(ctx, func() (err error) {
+			resp, err = api.GetConversationHistoryContext(ctx, history)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, resp.Messages...)
+		if !resp.HasMore || resp.ResponseMetaData.NextCursor == "" {
+			break
+		}
+		history.Cursor = resp.ResponseMetaData.NextCursor
+	}
+
+	// conversations.history returns the most recent messages first.
+	for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+		msgs[i], msgs[j] = msgs[j], msgs[i]
+	}
+
+	return msgs, nil
+}
+
+// timestampToTime converts a message timestamp, e.g. 1503435956.000247, to a time.
+func timestampToTime(ts string) time.Time {
+	sec, frac, _ := strings.Cut(ts, ".")
+	s, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	var us int64
+	if frac != "" {
+		us, _ = strconv.ParseInt((frac + "000000")[:6], 10, 64)
+	}
+
+	return time.Unix(s, us*int64(time.Microsecond)).UTC()
+}
+
+type exportedMessage struct {
+	Msg
+	Author string
+	Time   time.Time
+	Text   string
+}
+
+// userNameCache resolves and memoizes user names for the duration of a single call.
+type userNameCache struct {
+	resolve UserNameResolver
+	cache   map[string]string
+}
+
+func (n userNameCache) user(ctx context.Context, id string) string {
+	if name, ok := n.cache[id]; ok {
+		return name
+	}
+
+	name := id
+	if n.resolve != nil {
+		if resolved, err := n.resolve(ctx, id); err == nil && resolved != "" {
+			name = resolved
+		}
+	}
+	n.cache[id] = name
+
+	return name
+}
+
+func (n userNameCache) author(ctx context.Context, msg Msg) string {
+	switch {
+	case msg.User != "":
+		return n.user(ctx, msg.User)
+	case msg.Username != "":
+		return msg.Username
+	case msg.BotProfile != nil && msg.BotProfile.Name != "":
+		return msg.BotProfile.Name
+	default:
+		return msg.BotID
+	}
+}
+
+func renderMarkdownExport(w io.Writer, title string, msgs []exportedMessage) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n", title)
+	for _, msg := range msgs {
+		fmt.Fprintf(&b, "\n**%s** — %s\n", msg.Author, msg.Time.Format(time.RFC3339))
+		if msg.Text != "" {
+			fmt.Fprintf(&b, "\n%s\n", msg.Text)
+		}
+		if len(msg.Files) > 0 {
+			b.WriteString("\nFiles:\n")
+			for _, file := range msg.Files {
+				fmt.Fprintf(&b, "- [%s](%s)\n", exportFileName(file), file.Permalink)
+			}
+		}
+		if msg.ReplyCount > 0 {
+			fmt.Fprintf(&b, "\n_%d replies_\n", msg.ReplyCount)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func renderHTMLExport(w io.Writer, title string, msgs []exportedMessage) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(title))
+	b.WriteString("<style>body{font-family:sans-serif;max-width:50em;margin:auto}.message{border-bottom:1px solid #ddd;padding:.5em 0}.text{white-space:pre-wrap}time{color:#666}</style>\n")
+	fmt.Fprintf(&b, "</head>\n<body>\n<h1>%s</h1>\n", html.EscapeString(title))
+	for _, msg := range msgs {
+		b.WriteString("<div class=\"message\">\n")
+		fmt.Fprintf(&b, "<p><strong>%s</strong> <time datetime=\"%s\">%s</time></p>\n",
+			html.EscapeString(msg.Author), msg.Time.Format(time.RFC3339), msg.Time.Format("2006-01-02 15:04:05 MST"))
+		if msg.Text != "" {
+			fmt.Fprintf(&b, "<div class=\"text\">%s</div>\n", html.EscapeString(msg.Text))
+		}
+		if len(msg.Files) > 0 {
+			b.WriteString("<ul class=\"files\">\n")
+			for _, file := range msg.Files {
+				fmt.Fprintf(&b, "<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(file.Permalink), html.EscapeString(exportFileName(file)))
+			}
+			b.WriteString("</ul>\n")
+		}
+		if msg.ReplyCount > 0 {
+			fmt.Fprintf(&b, "<p><em>%d replies</em></p>\n", msg.ReplyCount)
+		}
+		b.WriteString("</div>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func exportFileName(file File) string {
+	if file.Title != "" {
+		return file.Title
+	}
+
+	return file.Name
+}
@@ -0,0 +1,104 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportResolver(ctx context.Context, userID string) (string, error) {
+	switch userID {
+	case "U1":
+		return "alice", nil
+	case "U2":
+		return "bob", nil
+	}
+	return "", fmt.Errorf("user_not_found")
+}
+
+func TestExportConversationMarkdown(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/conversations.history", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		if r.FormValue("cursor") == "" {
+			rw.Write([]byte(`{"ok":true,"has_more":true,"response_metadata":{"next_cursor":"page2"},"messages":[
+				{"type":"message","user":"U2","text":"thanks <@U1>, see <https://example.com|the runbook> &amp; <#C1|ops>","ts":"1700000060.000200","reply_count":2}
+			]}`))
+			return
+		}
+		rw.Write([]byte(`{"ok":true,"has_more":false,"messages":[
+			{"type":"message","user":"U1","text":"deploy failed <!here>","ts":"1700000000.000100",
+			 "files":[{"id":"F1","name":"trace.log","permalink":"https://example.slack.com/files/F1"}]}
+		]}`))
+	})
+	once.Do(startServer)
+	api := New("testing-token", OptionAPIURL("http://"+serverAddr+"/"))
+
+	var buf bytes.Buffer
+	err := api.ExportConversation(&buf, ConversationExportParameters{
+		ChannelID:   "C1",
+		Title:       "Incident 42",
+		ResolveUser: exportResolver,
+	})
+	require.NoError(t, err)
+
+	expected := "# Incident 42\n" +
+		"\n**alice** — 2023-11-14T22:13:20Z\n" +
+		"\ndeploy failed @here\n" +
+		"\nFiles:\n- [trace.log](https://example.slack.com/files/F1)\n" +
+		"\n**bob** — 2023-11-14T22:14:20Z\n" +
+		"\nthanks @alice, see [the runbook](https://example.com) & #ops\n" +
+		"\n_2 replies_\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestExportConversationThreadHTML(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	http.HandleFunc("/conversations.replies", func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1700000000.000100", r.FormValue("ts"))
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":true,"has_more":false,"messages":[
+			{"type":"message","user":"U1","text":"is &lt;b&gt; safe?","ts":"1700000000.000100"},
+			{"type":"message","user":"U9","text":"yes","ts":"1700000001.000100"}
+		]}`))
+	})
+	once.Do(startServer)
+	api := New("testing-token", OptionAPIURL("http://"+serverAddr+"/"))
+
+	var buf bytes.Buffer
+	err := api.ExportConversation(&buf, ConversationExportParameters{
+		ChannelID:   "C1",
+		ThreadTS:    "1700000000.000100",
+		Format:      ConversationExportHTML,
+		ResolveUser: exportResolver,
+	})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "<!DOCTYPE html>"))
+	assert.Contains(t, out, "<h1>C1</h1>")
+	assert.Contains(t, out, "<strong>alice</strong>")
+	assert.Contains(t, out, "<strong>U9</strong>")
+	assert.Contains(t, out, "is &lt;b&gt; safe?")
+	assert.Less(t, strings.Index(out, "is &lt;b&gt;"), strings.Index(out, ">yes<"))
+}
+
+func TestExportConversationUnsupportedFormat(t *testing.T) {
+	api := New("testing-token")
+
+	err := api.ExportConversation(&bytes.Buffer{}, ConversationExportParameters{ChannelID: "C1", Format: "pdf"})
+	assert.EqualError(t, err, `unsupported export format "pdf"`)
+}
+
+func TestTimestampToTime(t *testing.T) {
+	assert.Equal(t, time.Unix(1503435956, 247000).UTC(), timestampToTime("1503435956.000247"))
+	assert.Equal(t, time.Unix(1503435956, 0).UTC(), timestampToTime("1503435956"))
+	assert.True(t, timestampToTime("invalid").IsZero())
+}
@@ -0,0 +1,180 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/slack-go/slack/internal/errorsx"
+)
+
+// ErrBroadcastMentionDenied is returned by the built-in mention policies when a message
+// is not allowed to notify everyone in a conversation.
+const ErrBroadcastMentionDenied = errorsx.String("broadcast mention denied by policy")
+
+// BroadcastMention is a special mention notifying many members of a conversation at once.
+type BroadcastMention string
+
+const (
+	// BroadcastHere notifies the active members of a conversation.
+	BroadcastHere BroadcastMention = "here"
+	// BroadcastChannel notifies all the members of a conversation.
+	BroadcastChannel BroadcastMention = "channel"
+	// BroadcastEveryone notifies every member of the workspace, in its #general channel.
+	BroadcastEveryone BroadcastMention = "everyone"
+)
+
+// String returns the mrkdwn token of the mention, e.g. <!here>.
+func (m BroadcastMention) String() string {
+	return "<!" + string(m) + ">"
+}
+
+// RichTextElement returns the rich text element of the mention.
+func (m BroadcastMention) RichTextElement() *RichTextSectionBroadcastElement {
+	return NewRichTextSectionBroadcastElement(string(m))
+}
+
+var (
+	broadcastMentionPattern = regexp.MustCompile(`<!(here|channel|everyone)(\|[^>]*)?>`)
+	// bareBroadcastMentionPattern matches the @here, @channel and @everyone words Slack turns
+	// into broadcast mentions when names are linked, but not e-mail addresses.
+	bareBroadcastMentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@(here|channel|everyone)\b`)
+)
+
+// FindBroadcastMentions returns the distinct broadcast mentions found in mrkdwn text, i.e. the
+// <!here>, <!channel> and <!everyone> tokens. Bare @here, @channel and @everyone words are
+// only mentions when Slack links names, see MsgOptionLinkNames.
+func FindBroadcastMentions(text string) []BroadcastMention {
+	return findBroadcastMentions(nil, text, false)
+}
+
+func findBroadcastMentions(mentions []BroadcastMention, text string, bare bool) []BroadcastMention {
+	for _, match := range broadcastMentionPattern.FindAllStringSubmatch(text, -1) {
+		mentions = appendMention(mentions, BroadcastMention(match[1]))
+	}
+	if bare {
+		for _, match := range bareBroadcastMentionPattern.FindAllStringSubmatch(text, -1) {
+			mentions = appendMention(mentions, BroadcastMention(match[1]))
+		}
+	}
+
+	return mentions
+}
+
+func appendMention(mentions []BroadcastMention, m BroadcastMention) []BroadcastMention {
+	for _, existing := range mentions {
+		if existing == m {
+			return mentions
+		}
+	}
+
+	return append(mentions, m)
+}
+
+// MentionPolicy decides whether a message containing broadcast mentions may be sent to a
+// conversation. Returning a non-nil error prevents the message from being sent.
+type MentionPolicy func(ctx context.Context, channelID string, mentions []BroadcastMention) error
+
+// DenyBroadcastMentions returns a MentionPolicy rejecting the given mentions, or all the
+// broadcast mentions if none is given.
+func DenyBroadcastMentions(denied ...BroadcastMention) MentionPolicy {
+	return func(ctx context.Context, channelID string, mentions []BroadcastMention) error {
+		for _, m := range mentions {
+			if len(denied) == 0 {
+				return fmt.Errorf("%w: %s", ErrBroadcastMentionDenied, m)
+			}
+			for _, d := range denied {
+				if m == d {
+					return fmt.Errorf("%w: %s", ErrBroadcastMentionDenied, m)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// BusinessHoursMentionPolicy returns a MentionPolicy rejecting @channel and @everyone mentions
+// outside of business hours, i.e. on weekends or outside [startHour, endHour) in loc. @here
+// mentions are always allowed, as they only notify active members.
+func BusinessHoursMentionPolicy(loc *time.Location, startHour, endHour int) MentionPolicy {
+	return businessHoursMentionPolicy(loc, startHour, endHour, time.Now)
+}
+
+func businessHoursMentionPolicy(loc *time.Location, startHour, endHour int, now func() time.Time) MentionPolicy {
+	return func(ctx context.Context, channelID string, mentions []BroadcastMention) error {
+		t := now().In(loc)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday && t.Hour() >= startHour && t.Hour() < endHour {
+			return nil
+		}
+
+		return DenyBroadcastMentions(BroadcastChannel, BroadcastEveryone)(ctx, channelID, mentions)
+	}
+}
+
+// broadcastMentions returns the broadcast mentions of a message, looking at its text, its
+// attachments and its blocks. When Slack is asked to link names, with link_names or
+// parse=full, bare @here, @channel and @everyone words are mentions as well.
+func (t sendConfig) broadcastMentions() []BroadcastMention {
+	linkNames := t.values.Get("parse") == "full"
+	if v, err := strconv.ParseBool(t.values.Get("link_names")); err == nil && v {
+		linkNames = true
+	}
+
+	mentions := findBroadcastMentions(nil, t.values.Get("text"), linkNames)
+
+	// Attachments and blocks are held as JSON, where < and > are escaped, so they are decoded
+	// to look at every string they contain as well as at rich text broadcast elements.
+	for _, key := range []string{"attachments", "blocks"} {
+		var v interface{}
+		if err := json.Unmarshal([]byte(t.values.Get(key)), &v); err == nil {
+			mentions = jsonBroadcastMentions(mentions, v, linkNames)
+		}
+	}
+
+	return mentions
+}
+
+func jsonBroadcastMentions(mentions []BroadcastMention, v interface{}, bare bool) []BroadcastMention {
+	switch v := v.(type) {
+	case string:
+		mentions = findBroadcastMentions(mentions, v, bare)
+	case []interface{}:
+		for _, e := range v {
+			mentions = jsonBroadcastMentions(mentions, e, bare)
+		}
+	case map[string]interface{}:
+		if r, ok := v["range"].(string); ok && v["type"] == "broadcast" {
+			mentions = appendMention(mentions, BroadcastMention(r))
+		}
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			mentions = jsonBroadcastMentions(mentions, v[k], bare)
+		}
+	}
+
+	return mentions
+}
+
+// checkMentionPolicy applies the mention policy of the client, if any, to a message.
+func (api *Client) checkMentionPolicy(ctx context.Context, channelID string, config sendConfig) error {
+	if api.mentionPolicy == nil {
+		return nil
+	}
+
+	mentions := config.broadcastMentions()
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	return api.mentionPolicy(ctx, channelID, mentions)
+}
@@ -0,0 +1,102 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindBroadcastMentions(t *testing.T) {
+	mentions := FindBroadcastMentions("<!here> deploy done, <!channel|channel> and <!here|here> again <!subteam^S123>")
+	assert.Equal(t, []BroadcastMention{BroadcastHere, BroadcastChannel}, mentions)
+	assert.Nil(t, FindBroadcastMentions("&lt;!here&gt; is escaped"))
+	assert.Equal(t, "<!everyone>", BroadcastEveryone.String())
+
+	assert.Nil(t, FindBroadcastMentions("@channel is only a mention when names are linked"))
+	bare := findBroadcastMentions(nil, "@channel deploy, ping @here, mail ops@everyone.com or @everyone_team", true)
+	assert.Equal(t, []BroadcastMention{BroadcastChannel, BroadcastHere}, bare)
+}
+
+func TestBusinessHoursMentionPolicy(t *testing.T) {
+	// 2024-01-06 is a Saturday.
+	saturday := time.Date(2024, time.January, 6, 11, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, time.January, 8, 11, 0, 0, 0, time.UTC)
+
+	policy := businessHoursMentionPolicy(time.UTC, 9, 18, func() time.Time { return saturday })
+	err := policy(context.Background(), "C1", []BroadcastMention{BroadcastChannel})
+	assert.True(t, errors.Is(err, ErrBroadcastMentionDenied))
+	assert.NoError(t, policy(context.Background(), "C1", []BroadcastMention{BroadcastHere}))
+
+	policy = businessHoursMentionPolicy(time.UTC, 9, 18, func() time.Time { return monday })
+	assert.NoError(t, policy(context.Background(), "C1", []BroadcastMention{BroadcastChannel, BroadcastEveryone}))
+}
+
+func TestSendMessageMentionPolicy(t *testing.T) {
+	var posted int
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		posted++
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":true,"channel":"CXXX","ts":"1234567890.123456"}`))
+	}))
+	defer srv.Close()
+
+	var seen []BroadcastMention
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"), OptionMentionPolicy(
+		func(ctx context.Context, channelID string, mentions []BroadcastMention) error {
+			seen = mentions
+			return DenyBroadcastMentions(BroadcastChannel)(ctx, channelID, mentions)
+		},
+	))
+
+	_, _, err := api.PostMessage("CXXX", MsgOptionText(BroadcastChannel.String()+" standup!", false))
+	assert.True(t, errors.Is(err, ErrBroadcastMentionDenied))
+	assert.Equal(t, 0, posted)
+
+	_, _, err = api.PostMessage("CXXX", MsgOptionBlocks(
+		NewSectionBlock(NewTextBlockObject(MarkdownType, BroadcastChannel.String()+" standup!", false, false), nil, nil),
+	))
+	assert.ErrorIs(t, err, ErrBroadcastMentionDenied)
+
+	_, _, err = api.PostMessage("CXXX", MsgOptionAttachments(Attachment{
+		Fields: []AttachmentField{{Title: "Audience", Value: BroadcastChannel.String()}},
+	}))
+	assert.ErrorIs(t, err, ErrBroadcastMentionDenied)
+
+	_, _, err = api.PostMessage("CXXX", MsgOptionAttachments(Attachment{
+		Blocks: Blocks{BlockSet: []Block{NewRichTextBlock("rt", NewRichTextSection(BroadcastChannel.RichTextElement()))}},
+	}))
+	assert.ErrorIs(t, err, ErrBroadcastMentionDenied)
+	assert.Equal(t, 0, posted)
+
+	// Slack turns bare @channel words into mentions when names are linked.
+	_, _, err = api.PostMessage("CXXX", MsgOptionText("@channel deploy now", false), MsgOptionLinkNames(true))
+	assert.ErrorIs(t, err, ErrBroadcastMentionDenied)
+	_, _, err = api.PostMessage("CXXX", MsgOptionText("@channel deploy now", false), MsgOptionParse(true))
+	assert.ErrorIs(t, err, ErrBroadcastMentionDenied)
+	_, _, err = api.PostMessage("CXXX", MsgOptionAttachments(Attachment{Text: "@channel deploy now"}), MsgOptionLinkNames(true))
+	assert.ErrorIs(t, err, ErrBroadcastMentionDenied)
+	assert.Equal(t, 0, posted)
+
+	_, _, err = api.PostMessage("CXXX", MsgOptionBlocks(
+		NewRichTextBlock("rt", NewRichTextSection(BroadcastHere.RichTextElement())),
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []BroadcastMention{BroadcastHere}, seen)
+
+	seen = nil
+	var applied int
+	_, _, err = api.PostMessage("CXXX", MsgOptionText("no mentions", false), func(*sendConfig) error {
+		applied++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Nil(t, seen)
+	assert.Equal(t, 1, applied, "options must only be applied once")
+	assert.Equal(t, 2, posted)
+}
@@ -0,0 +1,372 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack/slackutilsx"
+)
+
+// ChannelNameResolver resolves a conversation ID to the name displayed in place of the ID.
+type ChannelNameResolver func(ctx context.Context, channelID string) (string, error)
+
+// EmojiResolver resolves an emoji short-code, without colons, to the text displayed in its
+// place, usually the unicode emoji. An empty result keeps the short-code.
+type EmojiResolver func(ctx context.Context, name string) (string, error)
+
+// MessageRenderFormat is the output format of a MessageRenderer.
+type MessageRenderFormat int
+
+const (
+	MessageRenderPlainText MessageRenderFormat = iota
+	MessageRenderMarkdown
+)
+
+// MessageRenderer converts messages to plain text or simple Markdown, e.g. to build notification
+// fallbacks, index messages or log them.
+//
+// The resolvers are optional, and called for every mention or emoji found. When a resolver is
+// nil or fails, the ID or short-code is kept as is. As resolving names usually calls Slack, the
+// resolvers should be backed by a cache, e.g. the userscache package.
+type MessageRenderer struct {
+	Format         MessageRenderFormat
+	ResolveUser    UserNameResolver
+	ResolveChannel ChannelNameResolver
+	ResolveEmoji   EmojiResolver
+}
+
+// Render renders the blocks of a message, or its text if it has no blocks, followed by its
+// attachments.
+func (r MessageRenderer) Render(ctx context.Context, msg Msg) string {
+	var parts []string
+	if len(msg.Blocks.BlockSet) > 0 {
+		parts = appendRendered(parts, r.RenderBlocks(ctx, msg.Blocks))
+	} else {
+		parts = appendRendered(parts, r.RenderText(ctx, msg.Text))
+	}
+
+	for _, attachment := range msg.Attachments {
+		parts = appendRendered(parts, r.renderAttachment(ctx, attachment))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func appendRendered(parts []string, s string) []string {
+	if s == "" {
+		return parts
+	}
+
+	return append(parts, s)
+}
+
+var (
+	mrkdwnBoldPattern   = regexp.MustCompile(`(^|[\s(])\*([^*\n]+)\*([\s.,;:!?)]|$)`)
+	mrkdwnItalicPattern = regexp.MustCompile(`(^|[\s(])_([^_\n]+)_([\s.,;:!?)]|$)`)
+	mrkdwnStrikePattern = regexp.MustCompile(`(^|[\s(])~([^~\n]+)~([\s.,;:!?)]|$)`)
+)
+
+// RenderText renders mrkdwn text, expanding mentions, links and emoji.
+func (r MessageRenderer) RenderText(ctx context.Context, text string) string {
+	var b strings.Builder
+	for _, token := range slackutilsx.Tokenize(text) {
+		b.WriteString(r.renderToken(ctx, token))
+	}
+
+	out := b.String()
+	if r.Format == MessageRenderMarkdown {
+		out = replaceMrkdwnStyle(mrkdwnBoldPattern, out, "$1**$2**$3")
+		out = replaceMrkdwnStyle(mrkdwnStrikePattern, out, "$1~~$2~~$3")
+	} else {
+		out = replaceMrkdwnStyle(mrkdwnBoldPattern, out, "$1$2$3")
+		out = replaceMrkdwnStyle(mrkdwnItalicPattern, out, "$1$2$3")
+		out = replaceMrkdwnStyle(mrkdwnStrikePattern, out, "$1$2$3")
+	}
+
+	return out
+}
+
+// replaceMrkdwnStyle replaces the styled spans matched by pattern. The patterns consume the
+// character following a span, so adjacent spans such as "*a* *b*" need a second pass.
+func replaceMrkdwnStyle(pattern *regexp.Regexp, text, repl string) string {
+	text = pattern.ReplaceAllString(text, repl)
+	return pattern.ReplaceAllString(text, repl)
+}
+
+func (r MessageRenderer) renderToken(ctx context.Context, token slackutilsx.Token) string {
+	switch token.Type {
+	case slackutilsx.TokenUserMention:
+		if token.Text != "" {
+			return "@" + token.Text
+		}
+		return "@" + r.userName(ctx, token.ID)
+	case slackutilsx.TokenChannel:
+		if token.Text != "" {
+			return "#" + token.Text
+		}
+		return "#" + r.channelName(ctx, token.ID)
+	case slackutilsx.TokenUserGroup, slackutilsx.TokenBroadcast:
+		if token.Text != "" {
+			return token.Text
+		}
+		return "@" + token.ID
+	case slackutilsx.TokenSpecial:
+		return token.Text
+	case slackutilsx.TokenURL:
+		return r.link(token.URL, token.Text)
+	case slackutilsx.TokenEmoji:
+		return r.emoji(ctx, token.ID, token.Raw)
+	default:
+		return token.Text
+	}
+}
+
+func (r MessageRenderer) link(url, text string) string {
+	switch {
+	case text == "" || text == url:
+		return url
+	case r.Format == MessageRenderMarkdown:
+		return "[" + text + "](" + url + ")"
+	default:
+		return text + " (" + url + ")"
+	}
+}
+
+// emoji resolves an emoji name, and returns fallback if it cannot be resolved.
+func (r MessageRenderer) emoji(ctx context.Context, name, fallback string) string {
+	if r.ResolveEmoji != nil {
+		if emoji, err := r.ResolveEmoji(ctx, name); err == nil && emoji != "" {
+			return emoji
+		}
+	}
+
+	return fallback
+}
+
+func (r MessageRenderer) userName(ctx context.Context, id string) string {
+	if r.ResolveUser != nil {
+		if name, err := r.ResolveUser(ctx, id); err == nil && name != "" {
+			return name
+		}
+	}
+
+	return id
+}
+
+func (r MessageRenderer) channelName(ctx context.Context, id string) string {
+	if r.ResolveChannel != nil {
+		if name, err := r.ResolveChannel(ctx, id); err == nil && name != "" {
+			return name
+		}
+	}
+
+	return id
+}
+
+// RenderBlocks renders the blocks carrying text, one per line. Interactive blocks, such as
+// actions and inputs, are left out.
+func (r MessageRenderer) RenderBlocks(ctx context.Context, blocks Blocks) string {
+	var parts []string
+	for _, block := range blocks.BlockSet {
+		parts = appendRendered(parts, r.renderBlock(ctx, block))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func (r MessageRenderer) renderBlock(ctx context.Context, block Block) string {
+	switch b := block.(type) {
+	case *SectionBlock:
+		parts := []string{r.renderTextObject(ctx, b.Text)}
+		for _, field := range b.Fields {
+			parts = appendRendered(parts, r.renderTextObject(ctx, field))
+		}
+		return strings.TrimPrefix(strings.Join(parts, "\n"), "\n")
+	case *HeaderBlock:
+		text := r.renderTextObject(ctx, b.Text)
+		if text != "" && r.Format == MessageRenderMarkdown {
+			return "## " + text
+		}
+		return text
+	case *ContextBlock:
+		var parts []string
+		for _, element := range b.ContextElements.Elements {
+			if text, ok := element.(*TextBlockObject); ok {
+				parts = appendRendered(parts, r.renderTextObject(ctx, text))
+			}
+		}
+		return strings.Join(parts, " ")
+	case *DividerBlock:
+		return "---"
+	case *ImageBlock:
+		alt := b.AltText
+		if b.Title != nil && b.Title.Text != "" {
+			alt = b.Title.Text
+		}
+		if r.Format == MessageRenderMarkdown && b.ImageURL != "" {
+			return "![" + alt + "](" + b.ImageURL + ")"
+		}
+		return alt
+	case *MarkdownBlock:
+		return b.Text
+	case *RichTextBlock:
+		return r.renderRichTextElements(ctx, b.Elements)
+	default:
+		return ""
+	}
+}
+
+func (r MessageRenderer) renderTextObject(ctx context.Context, text *TextBlockObject) string {
+	switch {
+	case text == nil:
+		return ""
+	case text.Type == MarkdownType:
+		return r.RenderText(ctx, text.Text)
+	default:
+		return text.Text
+	}
+}
+
+func (r MessageRenderer) renderAttachment(ctx context.Context, attachment Attachment) string {
+	if len(attachment.Blocks.BlockSet) > 0 {
+		return r.RenderBlocks(ctx, attachment.Blocks)
+	}
+
+	var parts []string
+	parts = appendRendered(parts, r.RenderText(ctx, attachment.Pretext))
+	parts = appendRendered(parts, attachment.AuthorName)
+	parts = appendRendered(parts, r.link(attachment.TitleLink, attachment.Title))
+	parts = appendRendered(parts, r.RenderText(ctx, attachment.Text))
+	for _, field := range attachment.Fields {
+		parts = appendRendered(parts, strings.TrimPrefix(field.Title+": "+r.RenderText(ctx, field.Value), ": "))
+	}
+	parts = appendRendered(parts, r.RenderText(ctx, attachment.Footer))
+
+	if len(parts) == 0 {
+		return attachment.Fallback
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func (r MessageRenderer) renderRichTextElements(ctx context.Context, elements []RichTextElement) string {
+	var parts []string
+	for _, element := range elements {
+		parts = appendRendered(parts, r.renderRichTextElement(ctx, element))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func (r MessageRenderer) renderRichTextElement(ctx context.Context, element RichTextElement) string {
+	switch e := element.(type) {
+	case *RichTextSection:
+		return r.renderRichTextSection(ctx, e.Elements)
+	case *RichTextQuote:
+		lines := strings.Split(r.renderRichTextSection(ctx, e.Elements), "\n")
+		return "> " + strings.Join(lines, "\n> ")
+	case *RichTextPreformatted:
+		text := r.renderRichTextSection(ctx, e.Elements)
+		if r.Format == MessageRenderMarkdown {
+			return "```\n" + text + "\n```"
+		}
+		return text
+	case *RichTextList:
+		indent := strings.Repeat("  ", e.Indent)
+		var lines []string
+		for i, item := range e.Elements {
+			bullet := "- "
+			if e.Style == RTEListOrdered {
+				bullet = strconv.Itoa(e.Offset+i+1) + ". "
+			}
+			lines = append(lines, indent+bullet+r.renderRichTextElement(ctx, item))
+		}
+		return strings.Join(lines, "\n")
+	default:
+		return ""
+	}
+}
+
+func (r MessageRenderer) renderRichTextSection(ctx context.Context, elements []RichTextSectionElement) string {
+	var b strings.Builder
+	for _, element := range elements {
+		switch e := element.(type) {
+		case *RichTextSectionTextElement:
+			b.WriteString(r.styled(e.Text, e.Style))
+		case *RichTextSectionUserElement:
+			b.WriteString(r.styled("@"+r.userName(ctx, e.UserID), e.Style))
+		case *RichTextSectionChannelElement:
+			b.WriteString(r.styled("#"+r.channelName(ctx, e.ChannelID), e.Style))
+		case *RichTextSectionUserGroupElement:
+			b.WriteString("@" + e.UsergroupID)
+		case *RichTextSectionTeamElement:
+			b.WriteString(e.TeamID)
+		case *RichTextSectionBroadcastElement:
+			b.WriteString("@" + e.Range)
+		case *RichTextSectionLinkElement:
+			b.WriteString(r.styled(r.link(e.URL, e.Text), e.Style))
+		case *RichTextSectionEmojiElement:
+			b.WriteString(r.richTextEmoji(ctx, e))
+		case *RichTextSectionDateElement:
+			if e.Fallback != nil {
+				b.WriteString(*e.Fallback)
+			} else {
+				b.WriteString(e.Timestamp.Time().UTC().Format(time.RFC1123))
+			}
+		case *RichTextSectionColorElement:
+			b.WriteString(e.Value)
+		}
+	}
+
+	return b.String()
+}
+
+func (r MessageRenderer) styled(text string, style *RichTextSectionTextStyle) string {
+	trimmed := strings.TrimSpace(text)
+	if style == nil || r.Format != MessageRenderMarkdown || trimmed == "" {
+		return text
+	}
+
+	var marker string
+	switch {
+	case style.Code:
+		marker = "`"
+	case style.Bold && style.Italic:
+		marker = "***"
+	case style.Bold:
+		marker = "**"
+	case style.Italic:
+		marker = "_"
+	case style.Strike:
+		marker = "~~"
+	default:
+		return text
+	}
+
+	// Markdown does not allow spaces right inside the markers.
+	start := strings.Index(text, trimmed)
+	return text[:start] + marker + trimmed + marker + text[start+len(trimmed):]
+}
+
+func (r MessageRenderer) richTextEmoji(ctx context.Context, e *RichTextSectionEmojiElement) string {
+	if e.Unicode != "" {
+		var emoji strings.Builder
+		for _, code := range strings.Split(e.Unicode, "-") {
+			n, err := strconv.ParseUint(code, 16, 32)
+			if err != nil {
+				emoji.Reset()
+				break
+			}
+			emoji.WriteRune(rune(n))
+		}
+		if emoji.Len() > 0 {
+			return emoji.String()
+		}
+	}
+
+	return r.emoji(ctx, e.Name, fmt.Sprintf(":%s:", e.Name))
+}
@@ -0,0 +1,121 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMessageRenderer(format MessageRenderFormat) MessageRenderer {
+	return MessageRenderer{
+		Format: format,
+		ResolveUser: func(ctx context.Context, userID string) (string, error) {
+			if userID == "U1" {
+				return "alice", nil
+			}
+			return "", errors.New("user_not_found")
+		},
+		ResolveChannel: func(ctx context.Context, channelID string) (string, error) {
+			return "general", nil
+		},
+		ResolveEmoji: func(ctx context.Context, name string) (string, error) {
+			if name == "tada" {
+				return "🎉", nil
+			}
+			return "", nil
+		},
+	}
+}
+
+func TestMessageRendererRenderText(t *testing.T) {
+	ctx := context.Background()
+	text := "*Hi* <@U1> and <@U2>, see <#C1> and <#C2|random> :tada: :unknown: at 12:30:45 " +
+		"<https://example.com|the docs> <https://example.com> <!here> <!subteam^S1|@oncall> " +
+		"<!date^1392734382^{date}|Feb 18, 2014> ~old~ _new_ 1 &lt; 2 &amp;&amp; 3 &gt; 2"
+
+	tests := []struct {
+		format   MessageRenderFormat
+		expected string
+	}{
+		{
+			MessageRenderPlainText,
+			"Hi @alice and @U2, see #general and #random 🎉 :unknown: at 12:30:45 " +
+				"the docs (https://example.com) https://example.com @here @oncall " +
+				"Feb 18, 2014 old new 1 < 2 && 3 > 2",
+		},
+		{
+			MessageRenderMarkdown,
+			"**Hi** @alice and @U2, see #general and #random 🎉 :unknown: at 12:30:45 " +
+				"[the docs](https://example.com) https://example.com @here @oncall " +
+				"Feb 18, 2014 ~~old~~ _new_ 1 < 2 && 3 > 2",
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, testMessageRenderer(test.format).RenderText(ctx, text))
+	}
+}
+
+func TestMessageRendererRender(t *testing.T) {
+	payload := `{
+		"text": "fallback",
+		"blocks": [
+			{"type": "header", "text": {"type": "plain_text", "text": "Deploy"}},
+			{"type": "section", "text": {"type": "mrkdwn", "text": "by <@U1>"}, "fields": [{"type": "mrkdwn", "text": "*env* prod"}]},
+			{"type": "divider"},
+			{"type": "rich_text", "elements": [
+				{"type": "rich_text_section", "elements": [
+					{"type": "text", "text": "done ", "style": {"bold": true}},
+					{"type": "emoji", "name": "white_check_mark", "unicode": "2705"},
+					{"type": "text", "text": " cc "},
+					{"type": "user", "user_id": "U1"},
+					{"type": "text", "text": " in "},
+					{"type": "channel", "channel_id": "C1"},
+					{"type": "text", "text": " "},
+					{"type": "link", "url": "https://example.com", "text": "logs"}
+				]},
+				{"type": "rich_text_list", "style": "ordered", "elements": [
+					{"type": "rich_text_section", "elements": [{"type": "text", "text": "build"}]},
+					{"type": "rich_text_section", "elements": [{"type": "text", "text": "ship"}]}
+				]},
+				{"type": "rich_text_quote", "elements": [{"type": "text", "text": "ship it"}]},
+				{"type": "rich_text_preformatted", "elements": [{"type": "text", "text": "make deploy"}]}
+			]},
+			{"type": "actions", "elements": [{"type": "button", "text": {"type": "plain_text", "text": "Rollback"}, "action_id": "rollback"}]}
+		],
+		"attachments": [
+			{"pretext": "Details", "title": "Build 42", "title_link": "https://ci.example.com/42", "fields": [{"title": "Duration", "value": "3m"}]},
+			{"fallback": "only a fallback"}
+		]
+	}`
+
+	var msg Msg
+	require.NoError(t, json.Unmarshal([]byte(payload), &msg))
+	ctx := context.Background()
+
+	assert.Equal(t, "Deploy\n"+
+		"by @alice\nenv prod\n"+
+		"---\n"+
+		"done ✅ cc @alice in #general logs (https://example.com)\n"+
+		"1. build\n2. ship\n"+
+		"> ship it\n"+
+		"make deploy\n"+
+		"Details\nBuild 42 (https://ci.example.com/42)\nDuration: 3m\n"+
+		"only a fallback", testMessageRenderer(MessageRenderPlainText).Render(ctx, msg))
+
+	assert.Equal(t, "## Deploy\n"+
+		"by @alice\n**env** prod\n"+
+		"---\n"+
+		"**done** ✅ cc @alice in #general [logs](https://example.com)\n"+
+		"1. build\n2. ship\n"+
+		"> ship it\n"+
+		"```\nmake deploy\n```\n"+
+		"Details\n[Build 42](https://ci.example.com/42)\nDuration: 3m\n"+
+		"only a fallback", testMessageRenderer(MessageRenderMarkdown).Render(ctx, msg))
+
+	assert.Equal(t, "hello @alice", testMessageRenderer(MessageRenderPlainText).Render(ctx, Msg{Text: "hello <@U1>"}))
+}
@@ -0,0 +1,92 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// RoundTripperFunc is an http.RoundTripper implemented by a function.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// RequestMiddleware wraps the sending of the requests of a Client, e.g. to add headers, record
+// and replay responses or enforce timeouts. It must call next to send the request.
+type RequestMiddleware func(next RoundTripperFunc) RoundTripperFunc
+
+// OptionRequestMiddleware adds middlewares wrapping every Web API and webhook request of the
+// client, as well as the handshake of its WebSocket connections. It can be given several times,
+// the first middleware given being the outermost one. Middlewares also wrap a client set with
+// OptionHTTPClient, whatever the order of the options.
+func OptionRequestMiddleware(middleware ...RequestMiddleware) func(*Client) {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// applyMiddleware returns next wrapped by the middlewares of the client.
+func (api *Client) applyMiddleware(next RoundTripperFunc) RoundTripperFunc {
+	return chainMiddleware(api.middleware, next)
+}
+
+// chainMiddleware returns next wrapped by middleware, the first one being the outermost.
+func chainMiddleware(middleware []RequestMiddleware, next RoundTripperFunc) RoundTripperFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		next = middleware[i](next)
+	}
+
+	return next
+}
+
+// middlewareClient sends the requests of a Client through its middlewares.
+type middlewareClient struct {
+	do RoundTripperFunc
+}
+
+func (c middlewareClient) Do(req *http.Request) (*http.Response, error) {
+	return c.do(req)
+}
+
+// DialWebsocketContext dials a WebSocket connection with dialer, or websocket.DefaultDialer if it
+// is nil, passing the handshake request through the request middlewares of the client. The
+// middlewares may change the URL and headers of the request, and see the handshake response.
+func (api *Client) DialWebsocketContext(ctx context.Context, dialer *websocket.Dialer, url string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+
+	if len(api.middleware) == 0 {
+		return dialer.DialContext(ctx, url, header)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header != nil {
+		req.Header = header.Clone()
+	}
+
+	var conn *websocket.Conn
+	resp, err := api.applyMiddleware(func(req *http.Request) (*http.Response, error) {
+		var (
+			resp *http.Response
+			err  error
+		)
+		conn, resp, err = dialer.DialContext(req.Context(), req.URL.String(), req.Header)
+		return resp, err
+	})(req)
+
+	// A middleware may fail after a successful handshake.
+	if err != nil && conn != nil {
+		conn.Close()
+		conn = nil
+	}
+
+	return conn, resp, err
+}
@@ -0,0 +1,104 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func headerMiddleware(name, value string, calls *[]string) RequestMiddleware {
+	return func(next RoundTripperFunc) RoundTripperFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			*calls = append(*calls, value)
+			req.Header.Add(name, value)
+			return next(req)
+		}
+	}
+}
+
+func TestOptionRequestMiddleware(t *testing.T) {
+	var headers [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Values("X-Proxy-Tag"))
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	var calls []string
+	api := New("testing-token",
+		OptionRequestMiddleware(headerMiddleware("X-Proxy-Tag", "outer", &calls)),
+		OptionAPIURL(srv.URL+"/"),
+		// The middlewares wrap the client regardless of the order of the options.
+		OptionHTTPClient(srv.Client()),
+		OptionRequestMiddleware(headerMiddleware("X-Proxy-Tag", "inner", &calls)),
+	)
+
+	_, err := api.AuthTest()
+	require.NoError(t, err)
+	require.NoError(t, api.PostWebhookContext(context.Background(), srv.URL+"/hook", &WebhookMessage{Text: "hello"}))
+
+	assert.Equal(t, []string{"outer", "inner", "outer", "inner"}, calls)
+	assert.Equal(t, [][]string{{"outer", "inner"}, {"outer", "inner"}}, headers)
+}
+
+func TestOptionRequestMiddlewareShortCircuit(t *testing.T) {
+	errOffline := errors.New("offline")
+	api := New("testing-token", OptionRequestMiddleware(func(next RoundTripperFunc) RoundTripperFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return nil, errOffline
+		}
+	}))
+
+	_, err := api.AuthTest()
+	assert.ErrorIs(t, err, errOffline)
+}
+
+func TestDialWebsocketContext(t *testing.T) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Proxy-Tag") != "dial" || r.Header.Get("Origin") != "https://api.slack.com" {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+		conn, err := upgrader.Upgrade(rw, r, nil)
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+	header := http.Header{"Origin": {"https://api.slack.com"}}
+
+	_, _, err := New("testing-token").DialWebsocketContext(context.Background(), nil, url, header)
+	assert.Error(t, err)
+
+	var calls []string
+	var status int
+	api := New("testing-token", OptionRequestMiddleware(
+		headerMiddleware("X-Proxy-Tag", "dial", &calls),
+		func(next RoundTripperFunc) RoundTripperFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				resp, err := next(req)
+				if resp != nil {
+					status = resp.StatusCode
+				}
+				return resp, err
+			}
+		},
+	))
+
+	conn, _, err := api.DialWebsocketContext(context.Background(), nil, url, header)
+	require.NoError(t, err)
+	conn.Close()
+	assert.Equal(t, []string{"dial"}, calls)
+	assert.Equal(t, http.StatusSwitchingProtocols, status)
+	assert.Empty(t, header.Get("X-Proxy-Tag"), "the caller header must not be modified")
+}
@@ -115,6 +115,9 @@ type SlackResponse struct {
 	Ok               bool                  `json:"ok"`
 	Error            string                `json:"error"`
 	Errors           []SlackResponseErrors `json:"errors,omitempty"`
+	Warning          string                `json:"warning,omitempty"`
+	Needed           string                `json:"needed,omitempty"`
+	Provided         string                `json:"provided,omitempty"`
 	ResponseMetadata ResponseMetadata      `json:"response_metadata"`
 }
 
@@ -125,6 +128,9 @@ type KickUserFromConversationSlackResponse struct {
 	Ok               bool                  `json:"ok"`
 	Error            string                `json:"error"`
 	Errors           []SlackResponseErrors `json:"-"`
+	Warning          string                `json:"warning,omitempty"`
+	Needed           string                `json:"needed,omitempty"`
+	Provided         string                `json:"provided,omitempty"`
 	ResponseMetadata ResponseMetadata      `json:"response_metadata"`
 }
 
@@ -177,7 +183,14 @@ func (s KickUserFromConversationSlackResponse) Err() error {
 		return nil
 	}
 
-	return SlackErrorResponse{Err: s.Error, Errors: s.Errors, ResponseMetadata: s.ResponseMetadata}
+	return SlackErrorResponse{
+		Err:              s.Error,
+		Errors:           s.Errors,
+		Warning:          s.Warning,
+		Needed:           s.Needed,
+		Provided:         s.Provided,
+		ResponseMetadata: s.ResponseMetadata,
+	}
 }
 
 func (t SlackResponse) Err() error {
@@ -192,18 +205,164 @@ func (t SlackResponse) Err() error {
 		return nil
 	}
 
-	return SlackErrorResponse{Err: t.Error, Errors: t.Errors, ResponseMetadata: t.ResponseMetadata}
+	return SlackErrorResponse{
+		Err:              t.Error,
+		Errors:           t.Errors,
+		Warning:          t.Warning,
+		Needed:           t.Needed,
+		Provided:         t.Provided,
+		ResponseMetadata: t.ResponseMetadata,
+	}
+}
+
+// APIError is implemented by the errors returned when Slack rejects a request,
+// giving access to the details Slack sent along with the error.
+type APIError interface {
+	error
+	// HTTPStatusCode returns the status code of the HTTP response.
+	HTTPStatusCode() int
+	// Warnings returns the warnings reported by Slack.
+	Warnings() []string
+	// Messages returns the detailed error messages reported by Slack, e.g. the
+	// schema violations behind an invalid_blocks error.
+	Messages() []string
+	// NeededScopes returns the OAuth scopes required by the method, if the token was missing one.
+	NeededScopes() []string
+	// ProvidedScopes returns the OAuth scopes granted to the token, if it was missing one.
+	ProvidedScopes() []string
 }
 
 // SlackErrorResponse brings along the metadata of errors returned by the Slack API.
 type SlackErrorResponse struct {
 	Err              string
 	Errors           []SlackResponseErrors
+	Warning          string
+	Needed           string
+	Provided         string
 	ResponseMetadata ResponseMetadata
 }
 
 func (r SlackErrorResponse) Error() string { return r.Err }
 
+// HTTPStatusCode returns the status code of the HTTP response, always 200 OK: Slack reports
+// API errors in the body of successful responses, and the responses with any other status
+// are returned as a StatusCodeError instead.
+func (r SlackErrorResponse) HTTPStatusCode() int {
+	return http.StatusOK
+}
+
+// Warnings returns the warnings reported by Slack, both at the top level of the
+// response and in its metadata, without duplicates.
+func (r SlackErrorResponse) Warnings() []string {
+	var warnings []string
+	seen := make(map[string]bool)
+	add := func(warning string) {
+		if warning != "" && !seen[warning] {
+			seen[warning] = true
+			warnings = append(warnings, warning)
+		}
+	}
+
+	if r.Warning != "" {
+		for _, warning := range strings.Split(r.Warning, ",") {
+			add(strings.TrimSpace(warning))
+		}
+	}
+	for _, warning := range r.ResponseMetadata.Warnings {
+		add(warning)
+	}
+
+	return warnings
+}
+
+// Messages returns the detailed error messages reported by Slack, both in the
+// response metadata and as plain strings in the errors list.
+func (r SlackErrorResponse) Messages() []string {
+	messages := append([]string(nil), r.ResponseMetadata.Messages...)
+	for _, e := range r.Errors {
+		if e.Message != nil {
+			messages = append(messages, *e.Message)
+		}
+	}
+
+	return messages
+}
+
+// NeededScopes returns the OAuth scopes required by the method.
+func (r SlackErrorResponse) NeededScopes() []string {
+	return splitScopes(r.Needed)
+}
+
+// ProvidedScopes returns the OAuth scopes granted to the token.
+func (r SlackErrorResponse) ProvidedScopes() []string {
+	return splitScopes(r.Provided)
+}
+
+// As allows errors.As to match a SlackErrorResponse against the error category
+// types MissingScopeError, InvalidArgumentsError and InvalidBlocksError, given
+// either as values or as pointers.
+func (r SlackErrorResponse) As(target interface{}) bool {
+	switch t := target.(type) {
+	case *MissingScopeError, **MissingScopeError:
+		if r.Err != "missing_scope" {
+			return false
+		}
+		e := MissingScopeError{r}
+		if p, ok := t.(**MissingScopeError); ok {
+			*p = &e
+		} else {
+			*t.(*MissingScopeError) = e
+		}
+	case *InvalidArgumentsError, **InvalidArgumentsError:
+		if r.Err != "invalid_arguments" && r.Err != "invalid_arg_name" && r.Err != "invalid_array_arg" {
+			return false
+		}
+		e := InvalidArgumentsError{r}
+		if p, ok := t.(**InvalidArgumentsError); ok {
+			*p = &e
+		} else {
+			*t.(*InvalidArgumentsError) = e
+		}
+	case *InvalidBlocksError, **InvalidBlocksError:
+		if r.Err != "invalid_blocks" && r.Err != "invalid_blocks_format" {
+			return false
+		}
+		e := InvalidBlocksError{r}
+		if p, ok := t.(**InvalidBlocksError); ok {
+			*p = &e
+		} else {
+			*t.(*InvalidBlocksError) = e
+		}
+	default:
+		return false
+	}
+
+	return true
+}
+
+func splitScopes(scopes string) []string {
+	if scopes == "" {
+		return nil
+	}
+
+	return strings.Split(scopes, ",")
+}
+
+// MissingScopeError is the error returned when the token lacks an OAuth scope required by the method.
+type MissingScopeError struct {
+	SlackErrorResponse
+}
+
+// InvalidArgumentsError is the error returned when the arguments of a method are invalid.
+type InvalidArgumentsError struct {
+	SlackErrorResponse
+}
+
+// InvalidBlocksError is the error returned when the blocks of a message or view are invalid.
+type InvalidBlocksError struct {
+	SlackErrorResponse
+}
+
 // RateLimitedError represents the rate limit response from slack
 type RateLimitedError struct {
 	RetryAfter time.Duration
@@ -459,11 +618,20 @@ func checkStatusCode(resp *http.Response, d Debug) error {
 
 type responseParser func(*http.Response) error
 
+// headerReader is implemented by the responses that need some of the headers of the HTTP
+// response they are parsed from.
+type headerReader interface {
+	readHeader(header http.Header)
+}
+
 func newJSONParser(dst interface{}) responseParser {
 	return func(resp *http.Response) error {
 		if dst == nil {
 			return nil
 		}
+		if r, ok := dst.(headerReader); ok {
+			r.readHeader(resp.Header)
+		}
 		return json.NewDecoder(resp.Body).Decode(dst)
 	}
 }
@@ -3,9 +3,13 @@ package slack
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"sync"
 	"testing"
 
@@ -400,3 +404,126 @@ func TestKickUserFromConversationSlackResponseWithErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestSlackErrorResponseDetails(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		check    func(t *testing.T, err error)
+		messages []string
+		warnings []string
+		needed   []string
+		provided []string
+	}{
+		{
+			name:  "missing scope",
+			input: `{"ok":false,"error":"missing_scope","needed":"chat:write","provided":"channels:read,users:read","warning":"superfluous_charset","response_metadata":{"warnings":["superfluous_charset"]}}`,
+			check: func(t *testing.T, err error) {
+				var scopeErr *MissingScopeError
+				if !errors.As(err, &scopeErr) {
+					t.Fatalf("expected a MissingScopeError, got %#v", err)
+				}
+				var blocksErr *InvalidBlocksError
+				if errors.As(err, &blocksErr) {
+					t.Error("did not expect an InvalidBlocksError")
+				}
+			},
+			warnings: []string{"superfluous_charset"},
+			needed:   []string{"chat:write"},
+			provided: []string{"channels:read", "users:read"},
+		},
+		{
+			name:  "invalid blocks",
+			input: `{"ok":false,"error":"invalid_blocks","errors":["invalid additional property: emoji [json-pointer:\/blocks\/0\/text]"],"response_metadata":{"messages":["[ERROR] must be more than 0 characters [json-pointer:\/blocks\/0\/text\/text]"]}}`,
+			check: func(t *testing.T, err error) {
+				var blocksErr *InvalidBlocksError
+				if !errors.As(err, &blocksErr) {
+					t.Fatalf("expected an InvalidBlocksError, got %#v", err)
+				}
+				if blocksErr.Error() != "invalid_blocks" {
+					t.Errorf("got %q; want invalid_blocks", blocksErr.Error())
+				}
+			},
+			messages: []string{
+				"[ERROR] must be more than 0 characters [json-pointer:/blocks/0/text/text]",
+				"invalid additional property: emoji [json-pointer:/blocks/0/text]",
+			},
+		},
+		{
+			name:  "invalid arguments",
+			input: `{"ok":false,"error":"invalid_arguments","response_metadata":{"messages":["[ERROR] missing required field: channel"]}}`,
+			check: func(t *testing.T, err error) {
+				var argsErr InvalidArgumentsError
+				if !errors.As(err, &argsErr) {
+					t.Fatalf("expected an InvalidArgumentsError, got %#v", err)
+				}
+			},
+			messages: []string{"[ERROR] missing required field: channel"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response SlackResponse
+			if err := json.Unmarshal([]byte(tt.input), &response); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+
+			err := fmt.Errorf("wrapped: %w", response.Err())
+			tt.check(t, err)
+
+			var apiErr APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an APIError, got %#v", err)
+			}
+			if apiErr.HTTPStatusCode() != http.StatusOK {
+				t.Errorf("got status %d; want %d", apiErr.HTTPStatusCode(), http.StatusOK)
+			}
+			if !reflect.DeepEqual(apiErr.Messages(), tt.messages) {
+				t.Errorf("got messages %q; want %q", apiErr.Messages(), tt.messages)
+			}
+			if !reflect.DeepEqual(apiErr.Warnings(), tt.warnings) {
+				t.Errorf("got warnings %q; want %q", apiErr.Warnings(), tt.warnings)
+			}
+			if !reflect.DeepEqual(apiErr.NeededScopes(), tt.needed) {
+				t.Errorf("got needed scopes %q; want %q", apiErr.NeededScopes(), tt.needed)
+			}
+			if !reflect.DeepEqual(apiErr.ProvidedScopes(), tt.provided) {
+				t.Errorf("got provided scopes %q; want %q", apiErr.ProvidedScopes(), tt.provided)
+			}
+		})
+	}
+}
+
+func TestAPIErrorStatusCode(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(status)
+		rw.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+	}))
+	defer srv.Close()
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+
+	for _, status = range []int{http.StatusOK, http.StatusBadGateway} {
+		_, err := api.AuthTest()
+
+		var apiErr APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected an APIError, got %#v", err)
+		}
+		if apiErr.HTTPStatusCode() != status {
+			t.Errorf("got status %d; want %d", apiErr.HTTPStatusCode(), status)
+		}
+	}
+}
+
+func TestStatusCodeErrorIsAPIError(t *testing.T) {
+	var apiErr APIError
+	if !errors.As(fmt.Errorf("wrapped: %w", StatusCodeError{Code: http.StatusBadGateway, Status: "502 Bad Gateway"}), &apiErr) {
+		t.Fatal("expected StatusCodeError to implement APIError")
+	}
+	if apiErr.HTTPStatusCode() != http.StatusBadGateway {
+		t.Errorf("got status %d; want %d", apiErr.HTTPStatusCode(), http.StatusBadGateway)
+	}
+}
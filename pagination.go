@@ -1,5 +1,7 @@
 package slack
 
+import "strings"
+
 // Paging contains paging information
 type Paging struct {
 	Count int `json:"count"`
@@ -18,3 +20,61 @@ type Pagination struct {
 	First      int `json:"first"`
 	Last       int `json:"last"`
 }
+
+// maxConversationsPageLimit is the largest limit accepted by conversations.history,
+// conversations.list and conversations.members, which return at most that many results a page.
+const maxConversationsPageLimit = 999
+
+// PageInfo describes a page of cursor paginated results.
+type PageInfo struct {
+	// NextCursor is the cursor of the next page, empty on the last page.
+	NextCursor string
+	// Requested is the limit sent with the request, zero when the default limit was used.
+	Requested int
+	// Returned is the number of results of the page.
+	Returned int
+	// Truncated reports that more results are available but the requested limit exceeds the
+	// largest page the method returns, so that Slack capped the page. Slack may return fewer
+	// results than requested on any page, which is not reported as a truncation.
+	Truncated bool
+	// Warnings are the warnings Slack returned with the page.
+	Warnings []string
+}
+
+// newPageInfo describes a page of a method returning at most maxLimit results a page, zero if
+// the method has no such cap.
+func newPageInfo(requested, maxLimit, returned int, nextCursor string, more bool, warnings []string) PageInfo {
+	return PageInfo{
+		NextCursor: nextCursor,
+		Requested:  requested,
+		Returned:   returned,
+		Truncated:  (more || nextCursor != "") && maxLimit > 0 && requested > maxLimit,
+		Warnings:   warnings,
+	}
+}
+
+// debugPageInfo logs the truncation of a page and the warnings returned with it, if any.
+func (api *Client) debugPageInfo(method string, info PageInfo) {
+	if info.Truncated {
+		api.Debugf("%s: got %d results, the limit of %d exceeds the largest page the method returns; cursor %s", method, info.Returned, info.Requested, info.NextCursor)
+	}
+	if len(info.Warnings) > 0 {
+		api.Debugf("%s: warnings %v", method, info.Warnings)
+	}
+}
+
+// pageWarnings merges the top level warning of a response, a comma separated list, with the
+// warnings of its metadata, dropping duplicates.
+func pageWarnings(warning string, metadata []string) []string {
+	var warnings []string
+	seen := make(map[string]bool)
+	for _, w := range append(strings.Split(warning, ","), metadata...) {
+		w = strings.TrimSpace(w)
+		if w != "" && !seen[w] {
+			seen[w] = true
+			warnings = append(warnings, w)
+		}
+	}
+
+	return warnings
+}
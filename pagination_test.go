@@ -0,0 +1,80 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPageInfo(t *testing.T) {
+	tests := []struct {
+		name      string
+		info      PageInfo
+		truncated bool
+	}{
+		{"capped page with cursor", newPageInfo(1000, 999, 999, "next", false, nil), true},
+		{"capped page with has_more", newPageInfo(1000, 999, 999, "", true, nil), true},
+		{"short page with cursor", newPageInfo(3, 999, 2, "next", false, nil), false},
+		{"capped last page", newPageInfo(1000, 999, 2, "", false, nil), false},
+		{"no cap", newPageInfo(1000, 0, 2, "next", false, nil), false},
+		{"default limit", newPageInfo(0, 999, 2, "next", false, nil), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.truncated, test.info.Truncated)
+		})
+	}
+
+	assert.Equal(t, []string{"superfluous_charset", "limit_capped"}, pageWarnings("superfluous_charset", []string{"limit_capped"}))
+	assert.Equal(t, []string{"limit_capped"}, pageWarnings("limit_capped", []string{"limit_capped"}))
+	assert.Equal(t, []string{"a", "b"}, pageWarnings(" a, b,", nil))
+	assert.Nil(t, pageWarnings("", nil))
+}
+
+func TestPageInfoTruncation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/conversations.members":
+			rw.Write([]byte(`{"ok":true,"members":["U1","U2"],"warning":"limit_capped","response_metadata":{"next_cursor":"next"}}`))
+		case "/conversations.history":
+			rw.Write([]byte(`{"ok":true,"messages":[{"ts":"1.0"}],"has_more":true}`))
+		case "/users.list":
+			rw.Write([]byte(`{"ok":true,"members":[{"id":"U1"}],"response_metadata":{"next_cursor":"next","warnings":["limit_capped"]}}`))
+		}
+	}))
+	defer srv.Close()
+
+	buf := bytes.NewBuffer(nil)
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"), OptionDebug(true), OptionLog(log.New(buf, "", 0)))
+
+	members, page, err := api.GetUsersInConversationPage(&GetUsersInConversationParameters{ChannelID: "C1", Limit: 1000})
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Equal(t, PageInfo{NextCursor: "next", Requested: 1000, Returned: 2, Truncated: true, Warnings: []string{"limit_capped"}}, page)
+	assert.Contains(t, buf.String(), "conversations.members: got 2 results, the limit of 1000 exceeds")
+
+	_, page, err = api.GetUsersInConversationPage(&GetUsersInConversationParameters{ChannelID: "C1", Limit: 100})
+	require.NoError(t, err)
+	assert.False(t, page.Truncated, "short pages are a normal part of cursor pagination")
+
+	history, err := api.GetConversationHistory(&GetConversationHistoryParameters{ChannelID: "C1", Limit: 1000})
+	require.NoError(t, err)
+	assert.True(t, history.Truncated)
+
+	history, err = api.GetConversationHistory(&GetConversationHistoryParameters{ChannelID: "C1", Limit: 10})
+	require.NoError(t, err)
+	assert.False(t, history.Truncated)
+
+	users, err := api.GetUsersPaginated(GetUsersOptionLimit(200)).Next(context.Background())
+	require.NoError(t, err)
+	assert.False(t, users.PageInfo.Truncated)
+	assert.Equal(t, []string{"limit_capped"}, users.PageInfo.Warnings)
+	assert.Contains(t, buf.String(), "users.list: warnings [limit_capped]")
+}
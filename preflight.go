@@ -0,0 +1,189 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Preflight check names.
+const (
+	PreflightCheckConnectivity = "connectivity"
+	PreflightCheckAuth         = "auth"
+	PreflightCheckScopes       = "scopes"
+	PreflightCheckAppToken     = "app_token"
+)
+
+// PreflightCheck is the outcome of a single check run by Preflight.
+type PreflightCheck struct {
+	Name     string
+	Ok       bool
+	Duration time.Duration
+	// Err is the reason of the failure of the check.
+	Err error
+}
+
+// PreflightReport is the outcome of Preflight, to be used e.g. by readiness probes.
+type PreflightReport struct {
+	Checks []PreflightCheck
+	// Latency is the round trip time of a call to api.test.
+	Latency time.Duration
+	// Auth is the identity of the token, when it is valid.
+	Auth *AuthTestResponse
+	// Scopes are the OAuth scopes granted to the token.
+	Scopes []string
+	// MissingScopes are the required scopes not granted to the token.
+	MissingScopes []string
+}
+
+// Ok reports whether all the checks passed.
+func (r *PreflightReport) Ok() bool {
+	return r.Err() == nil
+}
+
+// Err returns the errors of the failed checks, joined, or nil if they all passed.
+func (r *PreflightReport) Err() error {
+	var errs []error
+	for _, check := range r.Checks {
+		if !check.Ok {
+			errs = append(errs, fmt.Errorf("%s: %w", check.Name, check.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *PreflightReport) run(name string, check func() error) error {
+	start := time.Now()
+	err := check()
+	r.Checks = append(r.Checks, PreflightCheck{
+		Name:     name,
+		Ok:       err == nil,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+
+	return err
+}
+
+// PreflightOption options for the Preflight method call.
+type PreflightOption func(*preflightConfig)
+
+type preflightConfig struct {
+	requiredScopes []string
+	maxLatency     time.Duration
+	appToken       bool
+}
+
+// PreflightOptionRequiredScopes checks that the token was granted the given OAuth scopes.
+func PreflightOptionRequiredScopes(scopes ...string) PreflightOption {
+	return func(c *preflightConfig) {
+		c.requiredScopes = append(c.requiredScopes, scopes...)
+	}
+}
+
+// PreflightOptionMaxLatency fails the connectivity check when a round trip to Slack takes
+// longer than d.
+func PreflightOptionMaxLatency(d time.Duration) PreflightOption {
+	return func(c *preflightConfig) {
+		c.maxLatency = d
+	}
+}
+
+// PreflightOptionAppToken checks that the app-level token set with OptionAppLevelToken can open
+// Socket Mode connections, by calling apps.connections.open.
+//
+// This check is expensive: apps.connections.open is a Tier 1 method, limited to about one call a
+// minute, and every call reserves a Socket Mode connection URL. Only use it for a one-off check
+// at startup, never in a readiness or health probe run periodically, where it would rate limit
+// the Socket Mode connections of the app itself.
+func PreflightOptionAppToken() PreflightOption {
+	return func(c *preflightConfig) {
+		c.appToken = true
+	}
+}
+
+// Preflight checks that the client is ready to be used: Slack can be reached, the token is valid
+// and, depending on the options, it was granted the required scopes and the app-level token is
+// valid. Every check is run even if a previous one failed, except for the scopes, which need a
+// valid token.
+//
+// The report is always returned. The error is the same as PreflightReport.Err.
+func (api *Client) Preflight(ctx context.Context, options ...PreflightOption) (*PreflightReport, error) {
+	var config preflightConfig
+	for _, opt := range options {
+		opt(&config)
+	}
+
+	report := &PreflightReport{}
+
+	report.run(PreflightCheckConnectivity, func() error {
+		start := time.Now()
+		response := &SlackResponse{}
+		if err := api.postMethod(ctx, "api.test", url.Values{}, response); err != nil {
+			return err
+		}
+		report.Latency = time.Since(start)
+
+		if err := response.Err(); err != nil {
+			return err
+		}
+		if config.maxLatency > 0 && report.Latency > config.maxLatency {
+			return fmt.Errorf("latency of %s exceeds %s", report.Latency, config.maxLatency)
+		}
+
+		return nil
+	})
+
+	authErr := report.run(PreflightCheckAuth, func() (err error) {
+		report.Auth, report.Scopes, err = api.authTestScopes(ctx)
+		return err
+	})
+
+	if len(config.requiredScopes) > 0 && authErr == nil {
+		report.run(PreflightCheckScopes, func() error {
+			granted := make(map[string]struct{}, len(report.Scopes))
+			for _, scope := range report.Scopes {
+				granted[scope] = struct{}{}
+			}
+			for _, scope := range config.requiredScopes {
+				if _, ok := granted[scope]; !ok {
+					report.MissingScopes = append(report.MissingScopes, scope)
+				}
+			}
+
+			if len(report.MissingScopes) > 0 {
+				return fmt.Errorf("missing scopes %s", strings.Join(report.MissingScopes, ","))
+			}
+			return nil
+		})
+	}
+
+	if config.appToken {
+		report.run(PreflightCheckAppToken, func() error {
+			if api.appLevelToken == "" {
+				return errors.New("no app-level token configured")
+			}
+			_, _, err := api.StartSocketModeContext(ctx)
+			return err
+		})
+	}
+
+	return report, report.Err()
+}
+
+// authTestScopes calls auth.test and returns the scopes of the token along with its identity.
+func (api *Client) authTestScopes(ctx context.Context) (*AuthTestResponse, []string, error) {
+	response := &authTestResponseFull{}
+	if err := api.postMethod(ctx, "auth.test", url.Values{"token": {api.token}}, response); err != nil {
+		return nil, nil, err
+	}
+	if err := response.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return &response.AuthTestResponse, response.scopes, nil
+}
@@ -0,0 +1,76 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflight(t *testing.T) {
+	http.DefaultServeMux = new(http.ServeMux)
+	// auth.test and apps.connections.open are registered by other tests on the shared mux.
+	http.HandleFunc("/preflight/api.test", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":true}`))
+	})
+	http.HandleFunc("/preflight/auth.test", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		if r.FormValue("token") != "xoxb-valid" {
+			rw.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+			return
+		}
+		rw.Header().Set("X-OAuth-Scopes", "chat:write, channels:read")
+		rw.Write([]byte(`{"ok":true,"team_id":"T1","user_id":"U1","bot_id":"B1"}`))
+	})
+	http.HandleFunc("/preflight/apps.connections.open", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer xapp-valid" {
+			rw.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+			return
+		}
+		rw.Write([]byte(`{"ok":true,"url":"wss://example.com/link"}`))
+	})
+	once.Do(startServer)
+	apiURL := OptionAPIURL("http://" + serverAddr + "/preflight/")
+	ctx := context.Background()
+
+	t.Run("ready", func(t *testing.T) {
+		api := New("xoxb-valid", apiURL, OptionAppLevelToken("xapp-valid"))
+
+		report, err := api.Preflight(ctx, PreflightOptionRequiredScopes("chat:write"), PreflightOptionAppToken())
+		require.NoError(t, err)
+		assert.True(t, report.Ok())
+		assert.Equal(t, "B1", report.Auth.BotID)
+		assert.Equal(t, []string{"chat:write", "channels:read"}, report.Scopes)
+		assert.Positive(t, report.Latency)
+
+		var names []string
+		for _, check := range report.Checks {
+			names = append(names, check.Name)
+		}
+		assert.Equal(t, []string{PreflightCheckConnectivity, PreflightCheckAuth, PreflightCheckScopes, PreflightCheckAppToken}, names)
+	})
+
+	t.Run("missing scopes and invalid app token", func(t *testing.T) {
+		api := New("xoxb-valid", apiURL, OptionAppLevelToken("xapp-revoked"))
+
+		report, err := api.Preflight(ctx, PreflightOptionRequiredScopes("chat:write", "users:read", "files:write"), PreflightOptionAppToken())
+		require.Error(t, err)
+		assert.False(t, report.Ok())
+		assert.Equal(t, []string{"users:read", "files:write"}, report.MissingScopes)
+		assert.Equal(t, "scopes: missing scopes users:read,files:write\napp_token: invalid_auth", err.Error())
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		api := New("xoxb-revoked", apiURL)
+
+		report, err := api.Preflight(ctx, PreflightOptionRequiredScopes("chat:write"), PreflightOptionAppToken())
+		assert.EqualError(t, err, "auth: invalid_auth\napp_token: no app-level token configured")
+		assert.Nil(t, report.Auth)
+		assert.Len(t, report.Checks, 3)
+		assert.True(t, report.Checks[0].Ok)
+	})
+}
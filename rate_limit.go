@@ -0,0 +1,47 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// maxRateLimitedRetries is how many times retryRateLimited calls a rate limited function again
+// before giving up.
+const maxRateLimitedRetries = 5
+
+// rateLimited reports whether err is a RateLimitedError, and how long to wait before retrying.
+func rateLimited(err error) (time.Duration, bool) {
+	var rateLimitedError *RateLimitedError
+	if errors.As(err, &rateLimitedError) {
+		return rateLimitedError.RetryAfter, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for the given duration, or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// retryRateLimited calls fn again as long as it is rate limited, waiting as long as Slack asks,
+// up to maxRateLimitedRetries times. The RateLimitedError is returned once the retries are
+// exhausted.
+func retryRateLimited(ctx context.Context, fn func() error) error {
+	for retries := 0; ; retries++ {
+		err := fn()
+		wait, ok := rateLimited(err)
+		if !ok || retries >= maxRateLimitedRetries {
+			return err
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
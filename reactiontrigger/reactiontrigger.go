@@ -0,0 +1,243 @@
+// Package reactiontrigger runs handlers when messages collect a given number of reactions,
+// e.g. "when a message in #triage gets 3 :eyes:, open a ticket".
+package reactiontrigger
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// DefaultWindow is the Window of the rules which do not set one.
+const DefaultWindow = 24 * time.Hour
+
+// Handler is called when a rule is triggered.
+type Handler func(ctx context.Context, trigger Trigger)
+
+// Rule describes the reactions a message must collect to trigger a handler.
+type Rule struct {
+	// Name identifies the rule, and must be unique within an Engine.
+	Name string
+	// Channels restricts the rule to the given conversation IDs. The rule applies to every
+	// conversation when it is empty.
+	Channels []string
+	// Reaction is the emoji name, without colons. Skin tone variants count as the same reaction.
+	Reaction string
+	// Threshold is the number of distinct users who must react to trigger the rule.
+	Threshold int
+	// Window is how long the reactions to a message are remembered after the last one. Once a
+	// rule is triggered for a message, it is not triggered again for that message until the
+	// window has elapsed.
+	Window  time.Duration
+	Handler Handler
+}
+
+func (r *Rule) matches(channel, reaction string) bool {
+	if r.Reaction != reaction {
+		return false
+	}
+	if len(r.Channels) == 0 {
+		return true
+	}
+	for _, c := range r.Channels {
+		if c == channel {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Trigger describes the message which triggered a rule.
+type Trigger struct {
+	Rule      *Rule
+	Channel   string
+	Timestamp string
+	// ItemUser is the author of the message, when known.
+	ItemUser string
+	// Users are the users who reacted, in the order they did.
+	Users []string
+}
+
+// Engine tracks reactions and triggers the matching rules.
+type Engine struct {
+	store Store
+	mu    sync.RWMutex
+	rules []*Rule
+}
+
+// Option defines an option for an Engine
+type Option func(*Engine)
+
+// OptionStore sets the Store of the reaction counts, a MemoryStore by default. A shared Store
+// allows running several instances of an app.
+func OptionStore(store Store) Option {
+	return func(e *Engine) {
+		e.store = store
+	}
+}
+
+// New returns an Engine without rules.
+func New(options ...Option) *Engine {
+	e := &Engine{}
+	for _, opt := range options {
+		opt(e)
+	}
+
+	if e.store == nil {
+		e.store = NewMemoryStore()
+	}
+
+	return e
+}
+
+// AddRule registers a rule.
+func (e *Engine) AddRule(rule Rule) error {
+	switch {
+	case rule.Name == "":
+		return errors.New("reactiontrigger: rule name is required")
+	case rule.Reaction == "":
+		return errors.New("reactiontrigger: rule reaction is required")
+	case rule.Threshold < 1:
+		return errors.New("reactiontrigger: rule threshold must be at least 1")
+	case rule.Handler == nil:
+		return errors.New("reactiontrigger: rule handler is required")
+	}
+
+	rule.Reaction = normalizeReaction(rule.Reaction)
+	if rule.Window <= 0 {
+		rule.Window = DefaultWindow
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, r := range e.rules {
+		if r.Name == rule.Name {
+			return errors.New("reactiontrigger: duplicate rule " + rule.Name)
+		}
+	}
+	e.rules = append(e.rules, &rule)
+
+	return nil
+}
+
+func (e *Engine) matchingRules(channel, reaction string) []*Rule {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var rules []*Rule
+	for _, r := range e.rules {
+		if r.matches(channel, reaction) {
+			rules = append(rules, r)
+		}
+	}
+
+	return rules
+}
+
+// normalizeReaction strips colons and skin tone modifiers, e.g. :+1::skin-tone-2: becomes +1.
+func normalizeReaction(reaction string) string {
+	reaction = strings.Trim(reaction, ":")
+	if i := strings.Index(reaction, "::skin-tone-"); i >= 0 {
+		reaction = reaction[:i]
+	}
+
+	return reaction
+}
+
+func stateKey(rule *Rule, channel, timestamp string) string {
+	return rule.Name + "/" + channel + "/" + timestamp
+}
+
+// ReactionAdded records a reaction to a message, and synchronously calls the handlers of the
+// rules reaching their threshold.
+func (e *Engine) ReactionAdded(ctx context.Context, channel, timestamp, reaction, user, itemUser string) error {
+	reaction = normalizeReaction(reaction)
+
+	for _, rule := range e.matchingRules(channel, reaction) {
+		key := stateKey(rule, channel, timestamp)
+
+		users, err := e.store.Add(ctx, key, user, rule.Window)
+		if err != nil {
+			return err
+		}
+		if len(users) < rule.Threshold {
+			continue
+		}
+
+		first, err := e.store.MarkTriggered(ctx, key, rule.Window)
+		if err != nil {
+			return err
+		}
+		if !first {
+			continue
+		}
+
+		rule.Handler(ctx, Trigger{
+			Rule:      rule,
+			Channel:   channel,
+			Timestamp: timestamp,
+			ItemUser:  itemUser,
+			Users:     users,
+		})
+	}
+
+	return nil
+}
+
+// ReactionRemoved forgets a reaction to a message.
+func (e *Engine) ReactionRemoved(ctx context.Context, channel, timestamp, reaction, user string) error {
+	reaction = normalizeReaction(reaction)
+
+	for _, rule := range e.matchingRules(channel, reaction) {
+		if err := e.store.Remove(ctx, stateKey(rule, channel, timestamp), user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HandleEvent records the reactions to messages carried by an event, and ignores the other
+// events. It accepts both the Events API events of slackevents and the RTM events of slack, by
+// value or by pointer.
+func (e *Engine) HandleEvent(ctx context.Context, evt interface{}) error {
+	switch ev := evt.(type) {
+	case slackevents.EventsAPIEvent:
+		return e.HandleEvent(ctx, ev.InnerEvent.Data)
+	case *slackevents.EventsAPIEvent:
+		return e.HandleEvent(ctx, ev.InnerEvent.Data)
+	case slackevents.ReactionAddedEvent:
+		return e.HandleEvent(ctx, &ev)
+	case *slackevents.ReactionAddedEvent:
+		if ev.Item.Type == "message" {
+			return e.ReactionAdded(ctx, ev.Item.Channel, ev.Item.Timestamp, ev.Reaction, ev.User, ev.ItemUser)
+		}
+	case slackevents.ReactionRemovedEvent:
+		return e.HandleEvent(ctx, &ev)
+	case *slackevents.ReactionRemovedEvent:
+		if ev.Item.Type == "message" {
+			return e.ReactionRemoved(ctx, ev.Item.Channel, ev.Item.Timestamp, ev.Reaction, ev.User)
+		}
+	case slack.ReactionAddedEvent:
+		return e.HandleEvent(ctx, &ev)
+	case *slack.ReactionAddedEvent:
+		if ev.Item.Type == "message" {
+			return e.ReactionAdded(ctx, ev.Item.Channel, ev.Item.Timestamp, ev.Reaction, ev.User, ev.ItemUser)
+		}
+	case slack.ReactionRemovedEvent:
+		return e.HandleEvent(ctx, &ev)
+	case *slack.ReactionRemovedEvent:
+		if ev.Item.Type == "message" {
+			return e.ReactionRemoved(ctx, ev.Item.Channel, ev.Item.Timestamp, ev.Reaction, ev.User)
+		}
+	}
+
+	return nil
+}
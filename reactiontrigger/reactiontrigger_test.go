@@ -0,0 +1,110 @@
+package reactiontrigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+func reactionAdded(channel, ts, reaction, user string) *slackevents.ReactionAddedEvent {
+	return &slackevents.ReactionAddedEvent{
+		Type:     "reaction_added",
+		User:     user,
+		Reaction: reaction,
+		ItemUser: "U_AUTHOR",
+		Item:     slackevents.Item{Type: "message", Channel: channel, Timestamp: ts},
+	}
+}
+
+func TestEngineTriggersOnThreshold(t *testing.T) {
+	var triggers []Trigger
+	engine := New()
+	require.NoError(t, engine.AddRule(Rule{
+		Name:      "triage",
+		Channels:  []string{"C_TRIAGE"},
+		Reaction:  ":eyes:",
+		Threshold: 3,
+		Handler:   func(ctx context.Context, trigger Trigger) { triggers = append(triggers, trigger) },
+	}))
+	ctx := context.Background()
+
+	for _, evt := range []interface{}{
+		reactionAdded("C_TRIAGE", "1.0", "eyes", "U1"),
+		reactionAdded("C_TRIAGE", "1.0", "eyes", "U1"), // same user again
+		reactionAdded("C_OTHER", "1.0", "eyes", "U2"),  // other channel
+		reactionAdded("C_TRIAGE", "1.0", "tada", "U2"), // other reaction
+		reactionAdded("C_TRIAGE", "2.0", "eyes", "U2"), // other message
+		*reactionAdded("C_TRIAGE", "1.0", "eyes::skin-tone-3", "U2"),
+		&slack.ReactionRemovedEvent{User: "U2", Reaction: "eyes", Item: slack.ReactionItem{Type: "message", Channel: "C_TRIAGE", Timestamp: "1.0"}},
+		reactionAdded("C_TRIAGE", "1.0", "eyes", "U3"),
+	} {
+		require.NoError(t, engine.HandleEvent(ctx, evt))
+	}
+	assert.Empty(t, triggers)
+
+	require.NoError(t, engine.HandleEvent(ctx, slackevents.EventsAPIEvent{
+		InnerEvent: slackevents.EventsAPIInnerEvent{Data: reactionAdded("C_TRIAGE", "1.0", "eyes", "U4")},
+	}))
+	require.Len(t, triggers, 1)
+	assert.Equal(t, "triage", triggers[0].Rule.Name)
+	assert.Equal(t, "C_TRIAGE", triggers[0].Channel)
+	assert.Equal(t, "1.0", triggers[0].Timestamp)
+	assert.Equal(t, "U_AUTHOR", triggers[0].ItemUser)
+	assert.Equal(t, []string{"U1", "U3", "U4"}, triggers[0].Users)
+
+	// A rule only triggers once per message.
+	require.NoError(t, engine.HandleEvent(ctx, reactionAdded("C_TRIAGE", "1.0", "eyes", "U5")))
+	assert.Len(t, triggers, 1)
+}
+
+func TestEngineAddRuleValidation(t *testing.T) {
+	engine := New()
+	handler := func(ctx context.Context, trigger Trigger) {}
+
+	assert.Error(t, engine.AddRule(Rule{Reaction: "eyes", Threshold: 1, Handler: handler}))
+	assert.Error(t, engine.AddRule(Rule{Name: "a", Threshold: 1, Handler: handler}))
+	assert.Error(t, engine.AddRule(Rule{Name: "a", Reaction: "eyes", Handler: handler}))
+	assert.Error(t, engine.AddRule(Rule{Name: "a", Reaction: "eyes", Threshold: 1}))
+	require.NoError(t, engine.AddRule(Rule{Name: "a", Reaction: "eyes", Threshold: 1, Handler: handler}))
+	assert.Error(t, engine.AddRule(Rule{Name: "a", Reaction: "tada", Threshold: 1, Handler: handler}))
+}
+
+func TestMemoryStoreExpiry(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	users, err := store.Add(ctx, "k", "U1", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1"}, users)
+
+	first, err := store.MarkTriggered(ctx, "k", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, first)
+
+	now = now.Add(30 * time.Minute)
+	users, err = store.Add(ctx, "k", "U2", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1", "U2"}, users)
+
+	// The entry expires an hour after the last reaction.
+	now = now.Add(61 * time.Minute)
+	users, err = store.Add(ctx, "k", "U3", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U3"}, users)
+	first, err = store.MarkTriggered(ctx, "k", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, first)
+
+	now = now.Add(2 * time.Hour)
+	_, err = store.Add(ctx, "other", "U1", time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, store.entries, 1)
+}
@@ -0,0 +1,116 @@
+package reactiontrigger
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store keeps the reactions to the messages watched by the rules, each key expiring ttl after
+// it was last written.
+type Store interface {
+	// Add records that user reacted to key, and returns the distinct users who reacted to key,
+	// in the order they did.
+	Add(ctx context.Context, key, user string, ttl time.Duration) ([]string, error)
+	// Remove forgets the reaction of user to key.
+	Remove(ctx context.Context, key, user string) error
+	// MarkTriggered marks key as triggered, and reports whether it was not already.
+	MarkTriggered(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+type memoryEntry struct {
+	users     []string
+	triggered bool
+	expires   time.Time
+}
+
+// MemoryStore is a Store keeping the reactions in memory.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// entry returns the live entry of key, creating it if needed. It must be called with the
+// lock held.
+func (s *MemoryStore) entry(key string, ttl time.Duration) *memoryEntry {
+	now := s.now()
+
+	// Drop the expired entries once in a while, so that the store does not grow forever.
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, e := range s.entries {
+			if !now.Before(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	e, ok := s.entries[key]
+	if !ok || !now.Before(e.expires) {
+		e = &memoryEntry{}
+		s.entries[key] = e
+	}
+	e.expires = now.Add(ttl)
+
+	return e
+}
+
+// Add implements Store.
+func (s *MemoryStore) Add(ctx context.Context, key, user string, ttl time.Duration) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.entry(key, ttl)
+	for _, u := range e.users {
+		if u == user {
+			return append([]string(nil), e.users...), nil
+		}
+	}
+	e.users = append(e.users, user)
+
+	return append([]string(nil), e.users...), nil
+}
+
+// Remove implements Store.
+func (s *MemoryStore) Remove(ctx context.Context, key, user string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return nil
+	}
+
+	for i, u := range e.users {
+		if u == user {
+			e.users = append(e.users[:i], e.users[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// MarkTriggered implements Store.
+func (s *MemoryStore) MarkTriggered(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.entry(key, ttl)
+	if e.triggered {
+		return false, nil
+	}
+	e.triggered = true
+
+	return true, nil
+}
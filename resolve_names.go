@@ -0,0 +1,155 @@
+package slack
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack/slackutilsx"
+)
+
+// UserIDResolver resolves a username or display name to a user ID. It returns an empty ID,
+// and no error, when no user has that name.
+type UserIDResolver func(ctx context.Context, name string) (string, error)
+
+// ChannelIDResolver resolves a conversation name to its ID. It returns an empty ID, and no
+// error, when no conversation has that name.
+type ChannelIDResolver func(ctx context.Context, name string) (string, error)
+
+type nameResolvers struct {
+	users    UserIDResolver
+	channels ChannelIDResolver
+}
+
+// MsgOptionResolveNames replaces the @username and #channel-name tokens of the text of the message
+// with proper <@U…> and <#C…> mentions before sending it, which is more reliable than link_names
+// for display names. Names are resolved with the given resolvers, which should be backed by a
+// cache; either of them may be nil. Names that cannot be resolved are left as is, while an error
+// returned by a resolver prevents the message from being sent.
+func MsgOptionResolveNames(users UserIDResolver, channels ChannelIDResolver) MsgOption {
+	return func(config *sendConfig) error {
+		config.nameResolvers = &nameResolvers{users: users, channels: channels}
+		return nil
+	}
+}
+
+var (
+	userNameTokenPattern    = regexp.MustCompile(`(^|[\s(])@([\p{L}\p{N}][\p{L}\p{N}._-]*)`)
+	channelNameTokenPattern = regexp.MustCompile(`(^|[\s(])#([\p{L}\p{N}][\p{L}\p{N}_-]*)`)
+)
+
+// resolve replaces the names of text with mentions, leaving its entities untouched.
+func (r *nameResolvers) resolve(ctx context.Context, text string) (string, error) {
+	var b strings.Builder
+	for _, token := range slackutilsx.Tokenize(text) {
+		if token.Type != slackutilsx.TokenText {
+			b.WriteString(token.Raw)
+			continue
+		}
+
+		raw, err := r.replace(ctx, token.Raw, userNameTokenPattern, r.users, slackutilsx.Mention)
+		if err != nil {
+			return "", err
+		}
+		raw, err = r.replace(ctx, raw, channelNameTokenPattern, r.channels, slackutilsx.ChannelLink)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(raw)
+	}
+
+	return b.String(), nil
+}
+
+func (r *nameResolvers) replace(ctx context.Context, text string, pattern *regexp.Regexp, resolve func(context.Context, string) (string, error), mention func(string) string) (string, error) {
+	if resolve == nil {
+		return text, nil
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		name := text[m[4]:m[5]]
+		// Trailing punctuation is not part of the name, e.g. in "thanks @alice."
+		name = strings.TrimRight(name, ".-")
+		end := m[4] + len(name)
+
+		// Broadcasts are left to the mention policy and link_names.
+		if name == "here" || name == "channel" || name == "everyone" {
+			continue
+		}
+
+		id, err := resolve(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		if id == "" {
+			continue
+		}
+
+		b.WriteString(text[last:m[3]])
+		b.WriteString(mention(id))
+		last = end
+	}
+	b.WriteString(text[last:])
+
+	return b.String(), nil
+}
+
+// CachedChannelIDResolver returns a ChannelIDResolver looking conversations up in the result of
+// conversations.list, which is fetched again at most once every ttl, or only once if ttl is
+// zero. params selects the listed conversations; its cursor is ignored.
+func (api *Client) CachedChannelIDResolver(ttl time.Duration, params GetConversationsParameters) ChannelIDResolver {
+	var (
+		mu      sync.Mutex
+		ids     map[string]string
+		fetched time.Time
+	)
+
+	return func(ctx context.Context, name string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if ids == nil || (ttl > 0 && time.Since(fetched) >= ttl) {
+			channels, err := api.allConversations(ctx, params)
+			if err != nil {
+				return "", err
+			}
+
+			ids = make(map[string]string, len(channels))
+			for _, channel := range channels {
+				ids[channel.Name] = channel.ID
+			}
+			fetched = time.Now()
+		}
+
+		return ids[strings.ToLower(name)], nil
+	}
+}
+
+func (api *Client) allConversations(ctx context.Context, params GetConversationsParameters) ([]Channel, error) {
+	params.Cursor = ""
+
+	var all []Channel
+	for {
+		var (
+			channels []Channel
+			cursor   string
+		)
+		err := retryRateLimited(ctx, func() (err error) {
+			channels, cursor, err = api.GetConversationsContext(ctx, &params)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, channels...)
+		if cursor == "" {
+			return all, nil
+		}
+		params.Cursor = cursor
+	}
+}
@@ -0,0 +1,99 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mapResolver(ids map[string]string) func(context.Context, string) (string, error) {
+	return func(ctx context.Context, name string) (string, error) {
+		return ids[name], nil
+	}
+}
+
+func TestNameResolversResolve(t *testing.T) {
+	r := &nameResolvers{
+		users:    mapResolver(map[string]string{"alice": "U1", "bob.smith": "U2"}),
+		channels: mapResolver(map[string]string{"general": "C1", "dev-ops": "C2"}),
+	}
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"hi @alice", "hi <@U1>"},
+		{"@alice, ping @bob.smith.", "<@U1>, ping <@U2>."},
+		{"see #general and (#dev-ops)", "see <#C1> and (<#C2>)"},
+		{"@here @channel @nobody #nowhere", "@here @channel @nobody #nowhere"},
+		{"mail alice@example.com or visit example.com/#general", "mail alice@example.com or visit example.com/#general"},
+		{"<@U9|alice> <#C9|general> <https://example.com/@alice|@alice> :tada:@alice", "<@U9|alice> <#C9|general> <https://example.com/@alice|@alice> :tada:<@U1>"},
+	}
+	for _, test := range tests {
+		text, err := r.resolve(context.Background(), test.text)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, text)
+	}
+
+	errLookup := errors.New("lookup failed")
+	r.users = func(ctx context.Context, name string) (string, error) { return "", errLookup }
+	_, err := r.resolve(context.Background(), "hi @alice")
+	assert.ErrorIs(t, err, errLookup)
+
+	r.users = nil
+	text, err := r.resolve(context.Background(), "hi @alice in #general")
+	require.NoError(t, err)
+	assert.Equal(t, "hi @alice in <#C1>", text)
+}
+
+func TestMsgOptionResolveNames(t *testing.T) {
+	var text string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		text = r.FormValue("text")
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":true,"channel":"C1","ts":"1.0"}`))
+	}))
+	defer srv.Close()
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+
+	users := mapResolver(map[string]string{"alice": "U1"})
+	_, _, err := api.PostMessage("C1", MsgOptionText("thanks @alice!", false), MsgOptionResolveNames(users, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "thanks <@U1>!", text)
+
+	errLookup := errors.New("lookup failed")
+	_, _, err = api.PostMessage("C1", MsgOptionText("thanks @bob!", false), MsgOptionResolveNames(func(ctx context.Context, name string) (string, error) {
+		return "", errLookup
+	}, nil))
+	assert.ErrorIs(t, err, errLookup)
+}
+
+func TestCachedChannelIDResolver(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		calls++
+		rw.Header().Set("Content-Type", "application/json")
+		if r.FormValue("cursor") == "" {
+			rw.Write([]byte(`{"ok":true,"channels":[{"id":"C1","name":"general"}],"response_metadata":{"next_cursor":"next"}}`))
+			return
+		}
+		rw.Write([]byte(`{"ok":true,"channels":[{"id":"C2","name":"dev-ops"}]}`))
+	}))
+	defer srv.Close()
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+
+	resolve := api.CachedChannelIDResolver(time.Hour, GetConversationsParameters{Types: []string{"public_channel"}})
+	ctx := context.Background()
+	for name, expected := range map[string]string{"general": "C1", "Dev-Ops": "C2", "random": ""} {
+		id, err := resolve(ctx, name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, id, name)
+	}
+	assert.Equal(t, 2, calls, "conversations.list must be walked once")
+}
@@ -0,0 +1,245 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ListScheduledMessagesParameters selects the scheduled messages returned by
+// ListScheduledMessages.
+type ListScheduledMessagesParameters struct {
+	Channel string
+	TeamID  string
+	// Oldest and Latest restrict the list to the messages scheduled to be posted between them,
+	// a zero time meaning no bound.
+	Oldest time.Time
+	Latest time.Time
+	// Limit is the number of messages fetched per page, left to Slack if zero.
+	Limit int
+}
+
+// ScheduledMessageResult reports the outcome of deleting or rescheduling a single scheduled
+// message with DeleteScheduledMessages or RescheduleMessages. Both return a result for every
+// message processed, in the order they were given, and only return an error if the context is
+// done before all messages have been processed.
+type ScheduledMessageResult struct {
+	ID      string
+	Channel string
+	// NewID is the ID of the message scheduled in place of the original one by
+	// RescheduleMessages. It is only set on a failed result if both the original message and
+	// its replacement are still scheduled.
+	NewID string
+	Ok    bool
+	// Error is the error code returned by Slack for this message, e.g. invalid_scheduled_message_id,
+	// or the error message when the request itself failed.
+	Error string
+}
+
+type scheduledMessagesConfig struct {
+	interval time.Duration
+}
+
+// ScheduledMessagesOption configures DeleteScheduledMessages and RescheduleMessages.
+type ScheduledMessagesOption func(*scheduledMessagesConfig)
+
+// ScheduledMessagesOptionInterval sets the delay between the processing of two messages, 1.2
+// seconds by default to stay within the 50 calls a minute Slack allows to the methods involved.
+func ScheduledMessagesOptionInterval(d time.Duration) ScheduledMessagesOption {
+	return func(c *scheduledMessagesConfig) {
+		c.interval = d
+	}
+}
+
+func newScheduledMessagesConfig(options []ScheduledMessagesOption) scheduledMessagesConfig {
+	config := scheduledMessagesConfig{interval: 1200 * time.Millisecond}
+	for _, opt := range options {
+		opt(&config)
+	}
+
+	return config
+}
+
+// ListScheduledMessages returns all the scheduled messages matching params.
+// For more details, see ListScheduledMessagesContext documentation.
+func (api *Client) ListScheduledMessages(params ListScheduledMessagesParameters) ([]ScheduledMessage, error) {
+	return api.ListScheduledMessagesContext(context.Background(), params)
+}
+
+// ListScheduledMessagesContext returns all the scheduled messages matching params with a custom
+// context, following the cursor of chat.scheduledMessages.list and waiting whenever Slack rate
+// limits the calls.
+func (api *Client) ListScheduledMessagesContext(ctx context.Context, params ListScheduledMessagesParameters) ([]ScheduledMessage, error) {
+	page := GetScheduledMessagesParameters{
+		Channel: params.Channel,
+		TeamID:  params.TeamID,
+		Limit:   params.Limit,
+	}
+	if !params.Oldest.IsZero() {
+		page.Oldest = strconv.FormatInt(params.Oldest.Unix(), 10)
+	}
+	if !params.Latest.IsZero() {
+		page.Latest = strconv.FormatInt(params.Latest.Unix(), 10)
+	}
+
+	var all []ScheduledMessage
+	for {
+		var (
+			messages []ScheduledMessage
+			cursor   string
+		)
+		err := retryRateLimited(ctx, func() (err error) {
+			messages, cursor, err = api.GetScheduledMessagesContext(ctx, &page)
+			return err
+		})
+		if err != nil {
+			return all, err
+		}
+
+		all = append(all, messages...)
+		if cursor == "" {
+			return all, nil
+		}
+		page.Cursor = cursor
+	}
+}
+
+// DeleteScheduledMessages deletes pending scheduled messages.
+// For more details, see DeleteScheduledMessagesContext documentation.
+func (api *Client) DeleteScheduledMessages(messages []ScheduledMessage, options ...ScheduledMessagesOption) ([]ScheduledMessageResult, error) {
+	return api.DeleteScheduledMessagesContext(context.Background(), messages, options...)
+}
+
+// DeleteScheduledMessagesContext deletes any number of pending scheduled messages with a custom
+// context, e.g. as returned by ListScheduledMessagesContext. chat.deleteScheduledMessage only
+// accepts a single message, so the messages are deleted one after the other, paced by the
+// interval. Rate limited calls are retried once Slack allows it, and failing to delete a message
+// does not prevent the others from being deleted.
+func (api *Client) DeleteScheduledMessagesContext(ctx context.Context, messages []ScheduledMessage, options ...ScheduledMessagesOption) ([]ScheduledMessageResult, error) {
+	config := newScheduledMessagesConfig(options)
+	results := make([]ScheduledMessageResult, 0, len(messages))
+
+	for i, message := range messages {
+		if i > 0 && config.interval > 0 {
+			if err := sleepContext(ctx, config.interval); err != nil {
+				return results, err
+			}
+		}
+
+		err := api.deleteScheduledMessage(ctx, message.Channel, message.ID)
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+
+		result := ScheduledMessageResult{ID: message.ID, Channel: message.Channel, Ok: err == nil}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (api *Client) deleteScheduledMessage(ctx context.Context, channel, id string) error {
+	return retryRateLimited(ctx, func() error {
+		_, err := api.DeleteScheduledMessageContext(ctx, &DeleteScheduledMessageParameters{
+			Channel:            channel,
+			ScheduledMessageID: id,
+		})
+		return err
+	})
+}
+
+// RescheduleMessage moves a pending scheduled message to postAt.
+// For more details, see RescheduleMessageContext documentation.
+func (api *Client) RescheduleMessage(message ScheduledMessage, postAt time.Time, options ...MsgOption) (string, error) {
+	return api.RescheduleMessageContext(context.Background(), message, postAt, options...)
+}
+
+// RescheduleMessageContext moves a pending scheduled message to postAt with a custom context,
+// and returns the ID of the message scheduled in its place. Slack cannot update scheduled
+// messages, so the message is scheduled again before the original one is deleted. If the
+// deletion fails, the new message is deleted in turn, so that the message is never posted
+// twice nor lost.
+//
+// The new message is built from options, or from the text of the original message if there are
+// none: the blocks and attachments of scheduled messages are not returned by Slack, so messages
+// holding some must be given again.
+func (api *Client) RescheduleMessageContext(ctx context.Context, message ScheduledMessage, postAt time.Time, options ...MsgOption) (string, error) {
+	if len(options) == 0 {
+		options = []MsgOption{MsgOptionText(message.Text, false)}
+	}
+
+	var newID string
+	err := retryRateLimited(ctx, func() (err error) {
+		_, newID, err = api.ScheduleMessageContext(ctx, message.Channel, strconv.FormatInt(postAt.Unix(), 10), options...)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := api.deleteScheduledMessage(ctx, message.Channel, message.ID); err != nil {
+		// The context may be done already, the rollback must not be canceled with it.
+		if rollbackErr := api.deleteScheduledMessage(context.WithoutCancel(ctx), message.Channel, newID); rollbackErr != nil {
+			return newID, fmt.Errorf("failed to delete scheduled message %s: %w, and to delete its replacement %s: %v", message.ID, err, newID, rollbackErr)
+		}
+		return "", err
+	}
+
+	return newID, nil
+}
+
+// ScheduledMessageReschedule is a scheduled message to move with RescheduleMessages.
+type ScheduledMessageReschedule struct {
+	Message ScheduledMessage
+	PostAt  time.Time
+	// Options build the new message, see RescheduleMessageContext.
+	Options []MsgOption
+}
+
+// RescheduleMessages moves pending scheduled messages.
+// For more details, see RescheduleMessagesContext documentation.
+func (api *Client) RescheduleMessages(reschedules []ScheduledMessageReschedule, options ...ScheduledMessagesOption) ([]ScheduledMessageResult, error) {
+	return api.RescheduleMessagesContext(context.Background(), reschedules, options...)
+}
+
+// RescheduleMessagesContext moves any number of pending scheduled messages with a custom
+// context, one after the other as RescheduleMessageContext does, paced by the interval. Failing
+// to move a message does not prevent the others from being moved.
+func (api *Client) RescheduleMessagesContext(ctx context.Context, reschedules []ScheduledMessageReschedule, options ...ScheduledMessagesOption) ([]ScheduledMessageResult, error) {
+	config := newScheduledMessagesConfig(options)
+	results := make([]ScheduledMessageResult, 0, len(reschedules))
+
+	for i, reschedule := range reschedules {
+		if i > 0 && config.interval > 0 {
+			if err := sleepContext(ctx, config.interval); err != nil {
+				return results, err
+			}
+		}
+
+		newID, err := api.RescheduleMessageContext(ctx, reschedule.Message, reschedule.PostAt, reschedule.Options...)
+		if ctx.Err() != nil && newID == "" {
+			return results, ctx.Err()
+		}
+
+		result := ScheduledMessageResult{
+			ID:      reschedule.Message.ID,
+			Channel: reschedule.Message.Channel,
+			NewID:   newID,
+			Ok:      err == nil,
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+	}
+
+	return results, nil
+}
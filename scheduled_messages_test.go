@@ -0,0 +1,184 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListScheduledMessages(t *testing.T) {
+	var (
+		calls   int
+		queries []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		calls++
+		rw.Header().Set("Content-Type", "application/json")
+		if calls == 2 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		queries = append(queries, r.Form.Get("oldest")+"-"+r.Form.Get("latest")+"-"+r.Form.Get("cursor"))
+
+		if r.Form.Get("cursor") == "" {
+			rw.Write([]byte(`{"ok":true,"scheduled_messages":[{"id":"Q1","channel_id":"C1","post_at":1767605400,"text":"one"}],"response_metadata":{"next_cursor":"page2"}}`))
+			return
+		}
+		rw.Write([]byte(`{"ok":true,"scheduled_messages":[{"id":"Q2","channel_id":"C1","post_at":1767609000,"text":"two"}],"response_metadata":{"next_cursor":""}}`))
+	}))
+	defer srv.Close()
+
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+	messages, err := api.ListScheduledMessages(ListScheduledMessagesParameters{
+		Channel: "C1",
+		Oldest:  time.Unix(1767600000, 0),
+		Latest:  time.Unix(1767700000, 0),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []ScheduledMessage{
+		{ID: "Q1", Channel: "C1", PostAt: 1767605400, Text: "one"},
+		{ID: "Q2", Channel: "C1", PostAt: 1767609000, Text: "two"},
+	}, messages)
+	assert.Equal(t, []string{"1767600000-1767700000-", "1767600000-1767700000-page2"}, queries)
+	assert.Equal(t, 3, calls, "the rate limited page must be fetched again")
+}
+
+func TestDeleteScheduledMessages(t *testing.T) {
+	var (
+		deleted          []string
+		rateLimitedCalls int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		rw.Header().Set("Content-Type", "application/json")
+		id := r.Form.Get("scheduled_message_id")
+		switch id {
+		case "Q404":
+			rw.Write([]byte(`{"ok":false,"error":"invalid_scheduled_message_id"}`))
+		case "Q429":
+			rateLimitedCalls++
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+		default:
+			deleted = append(deleted, r.Form.Get("channel")+"/"+id)
+			rw.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	defer srv.Close()
+
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+	start := time.Now()
+	results, err := api.DeleteScheduledMessages([]ScheduledMessage{
+		{ID: "Q1", Channel: "C1"},
+		{ID: "Q404", Channel: "C1"},
+		{ID: "Q2", Channel: "C2"},
+	}, ScheduledMessagesOptionInterval(20*time.Millisecond))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "deletions must be paced")
+	assert.Equal(t, []ScheduledMessageResult{
+		{ID: "Q1", Channel: "C1", Ok: true},
+		{ID: "Q404", Channel: "C1", Error: "invalid_scheduled_message_id"},
+		{ID: "Q2", Channel: "C2", Ok: true},
+	}, results)
+	assert.Equal(t, []string{"C1/Q1", "C2/Q2"}, deleted)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	results, err = api.DeleteScheduledMessagesContext(ctx, []ScheduledMessage{{ID: "Q3", Channel: "C1"}, {ID: "Q4", Channel: "C1"}}, ScheduledMessagesOptionInterval(time.Minute))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []ScheduledMessageResult{{ID: "Q3", Channel: "C1", Ok: true}}, results)
+
+	// Rate limited deletions are only retried a few times.
+	results, err = api.DeleteScheduledMessages([]ScheduledMessage{{ID: "Q429", Channel: "C1"}}, ScheduledMessagesOptionInterval(0))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Ok)
+	assert.Contains(t, results[0].Error, "rate limit")
+	assert.Equal(t, maxRateLimitedRetries+1, rateLimitedCalls)
+}
+
+func TestRescheduleMessages(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		calls     []string
+		scheduled = map[string]string{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		mu.Lock()
+		defer mu.Unlock()
+		rw.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/chat.scheduleMessage":
+			if r.Form.Get("channel") == "CFULL" {
+				rw.Write([]byte(`{"ok":false,"error":"restricted_too_many"}`))
+				return
+			}
+			id := "N" + r.Form.Get("post_at")
+			calls = append(calls, "schedule "+id)
+			scheduled[id] = r.Form.Get("text") + r.Form.Get("blocks")
+			json.NewEncoder(rw).Encode(map[string]interface{}{"ok": true, "channel": r.Form.Get("channel"), "scheduled_message_id": id})
+		case "/chat.deleteScheduledMessage":
+			id := r.Form.Get("scheduled_message_id")
+			calls = append(calls, "delete "+id)
+			if strings.HasPrefix(id, "QGONE") {
+				rw.Write([]byte(`{"ok":false,"error":"invalid_scheduled_message_id"}`))
+				return
+			}
+			if id == "N3" {
+				rw.Write([]byte(`{"ok":false,"error":"internal_error"}`))
+				return
+			}
+			delete(scheduled, id)
+			rw.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	defer srv.Close()
+
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+	newID, err := api.RescheduleMessage(ScheduledMessage{ID: "Q0", Channel: "C1", Text: "hello"}, time.Unix(10, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "N10", newID)
+	assert.Equal(t, "hello", scheduled["N10"])
+
+	calls = nil
+	results, err := api.RescheduleMessages([]ScheduledMessageReschedule{
+		{
+			Message: ScheduledMessage{ID: "Q1", Channel: "C1", Text: "old"},
+			PostAt:  time.Unix(1, 0),
+			Options: []MsgOption{MsgOptionText("new", false)},
+		},
+		{Message: ScheduledMessage{ID: "QGONE2", Channel: "C1"}, PostAt: time.Unix(2, 0)},
+		{Message: ScheduledMessage{ID: "QGONE3", Channel: "C1"}, PostAt: time.Unix(3, 0)},
+		{Message: ScheduledMessage{ID: "Q4", Channel: "CFULL"}, PostAt: time.Unix(4, 0)},
+	}, ScheduledMessagesOptionInterval(0))
+	require.NoError(t, err)
+
+	require.Len(t, results, 4)
+	assert.Equal(t, ScheduledMessageResult{ID: "Q1", Channel: "C1", NewID: "N1", Ok: true}, results[0])
+	assert.Equal(t, ScheduledMessageResult{ID: "QGONE2", Channel: "C1", Error: "invalid_scheduled_message_id"}, results[1])
+	assert.Equal(t, "N3", results[2].NewID, "the replacement could not be rolled back")
+	assert.False(t, results[2].Ok)
+	assert.Contains(t, results[2].Error, "internal_error")
+	assert.Equal(t, ScheduledMessageResult{ID: "Q4", Channel: "CFULL", Error: "restricted_too_many"}, results[3])
+
+	assert.Equal(t, "new", scheduled["N1"])
+	assert.NotContains(t, scheduled, "N2")
+	assert.Equal(t, []string{
+		"schedule N1", "delete Q1",
+		"schedule N2", "delete QGONE2", "delete N2",
+		"schedule N3", "delete QGONE3", "delete N3",
+	}, calls)
+}
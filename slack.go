@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 )
 
 const (
@@ -51,6 +52,15 @@ type AuthTestResponse struct {
 type authTestResponseFull struct {
 	SlackResponse
 	AuthTestResponse
+
+	// scopes granted to the token, which Slack only sends in the X-OAuth-Scopes header.
+	scopes []string
+}
+
+func (t *authTestResponseFull) readHeader(header http.Header) {
+	for _, scope := range splitScopes(header.Get("X-OAuth-Scopes")) {
+		t.scopes = append(t.scopes, strings.TrimSpace(scope))
+	}
 }
 
 // Client for the slack api.
@@ -63,6 +73,9 @@ type Client struct {
 	configRefreshToken string
 	endpoint           string
 	debug              bool
+	jsonRequests       bool
+	mentionPolicy      MentionPolicy
+	middleware         []RequestMiddleware
 	log                ilogger
 	httpclient         httpClient
 }
@@ -84,6 +97,23 @@ func OptionDebug(b bool) func(*Client) {
 	}
 }
 
+// OptionJSONRequests sends chat.postMessage, chat.update and chat.scheduleMessage requests
+// as JSON bodies authenticated with a bearer token, instead of url encoded forms.
+func OptionJSONRequests() func(*Client) {
+	return func(c *Client) {
+		c.jsonRequests = true
+	}
+}
+
+// OptionMentionPolicy sets a policy that every message sent with SendMessageContext, and the
+// methods built on it, must satisfy when it contains @here, @channel or @everyone mentions.
+// Messages posted to incoming webhooks, with PostWebhook or a WebhookClient, are not checked.
+func OptionMentionPolicy(policy MentionPolicy) func(*Client) {
+	return func(c *Client) {
+		c.mentionPolicy = policy
+	}
+}
+
 // OptionLog set logging for client.
 func OptionLog(l logger) func(*Client) {
 	return func(c *Client) {
@@ -124,6 +154,10 @@ func New(token string, options ...Option) *Client {
 		opt(s)
 	}
 
+	if len(s.middleware) > 0 {
+		s.httpclient = middlewareClient{do: s.applyMiddleware(s.httpclient.Do)}
+	}
+
 	return s
 }
 
@@ -2,6 +2,7 @@ package slackevents
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -376,6 +377,125 @@ func TestMessageEvent(t *testing.T) {
 
 }
 
+func TestMessageEventWithAttachmentBlocks(t *testing.T) {
+	rawE := []byte(`
+		{
+			"type": "message",
+			"subtype": "bot_message",
+			"bot_id": "B0123ABCDEF",
+			"text": "",
+			"ts": "1700000000.000100",
+			"channel": "C024BE91L",
+			"event_ts": "1700000000.000100",
+			"channel_type": "channel",
+			"attachments": [
+				{
+					"id": 1,
+					"color": "36a64f",
+					"fallback": "[no preview available]",
+					"blocks": [
+						{
+							"type": "rich_text",
+							"block_id": "Vrzsu",
+							"elements": [
+								{
+									"type": "rich_text_section",
+									"elements": [
+										{"type": "text", "text": "Incident opened by "},
+										{"type": "user", "user_id": "U123ABC456"},
+										{"type": "canvas", "canvas_id": "F0123"}
+									]
+								}
+							]
+						},
+						{
+							"type": "actions",
+							"block_id": "actions",
+							"elements": [
+								{"type": "workflow_button", "text": {"type": "plain_text", "text": "Acknowledge"}}
+							]
+						},
+						{
+							"type": "card",
+							"block_id": "card",
+							"title": {"type": "mrkdwn", "text": "Incident #42"}
+						}
+					]
+				}
+			]
+		}
+	`)
+
+	var e MessageEvent
+	if err := json.Unmarshal(rawE, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(e.Message.Attachments) != 1 {
+		t.Fatalf("expected 1 attachment, got %d", len(e.Message.Attachments))
+	}
+
+	blocks := e.Message.Attachments[0].Blocks.BlockSet
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(blocks))
+	}
+	if _, ok := blocks[0].(*slack.RichTextBlock); !ok {
+		t.Errorf("expected a rich text block, got %T", blocks[0])
+	}
+	if actions, ok := blocks[1].(*slack.ActionBlock); !ok || actions.Elements.ElementSet[0].ElementType() != "workflow_button" {
+		t.Errorf("expected an actions block with a workflow_button element, got %#v", blocks[1])
+	}
+	if unknown, ok := blocks[2].(*slack.UnknownBlock); !ok || unknown.BlockType() != "card" || len(unknown.Raw) == 0 {
+		t.Errorf("expected an unknown card block with its raw payload, got %#v", blocks[2])
+	}
+}
+
+func TestMessageEventWithMalformedAttachmentBlock(t *testing.T) {
+	rawE := []byte(`
+		{
+			"type": "message",
+			"subtype": "bot_message",
+			"bot_id": "B0123ABCDEF",
+			"text": "",
+			"ts": "1700000000.000100",
+			"channel": "C024BE91L",
+			"event_ts": "1700000000.000100",
+			"channel_type": "channel",
+			"attachments": [
+				{
+					"id": 1,
+					"fallback": "Deploy finished",
+					"blocks": [
+						{"type": "section", "block_id": "summary", "text": "Deploy finished"},
+						{"type": "divider", "block_id": "divider"}
+					]
+				}
+			]
+		}
+	`)
+
+	var e MessageEvent
+	if err := json.Unmarshal(rawE, &e); err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := e.Message.Attachments[0].Blocks.BlockSet
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 blocks, got %d", len(blocks))
+	}
+	unknown, ok := blocks[0].(*slack.UnknownBlock)
+	if !ok {
+		t.Fatalf("expected the malformed section to be kept as an unknown block, got %T", blocks[0])
+	}
+	var decodeErr *slack.BlockDecodeError
+	if !errors.As(unknown.Err, &decodeErr) || decodeErr.Type != slack.MBTSection {
+		t.Errorf("expected a decode error for the section block, got %#v", unknown.Err)
+	}
+	if _, ok := blocks[1].(*slack.DividerBlock); !ok {
+		t.Errorf("expected a divider block, got %T", blocks[1])
+	}
+}
+
 func TestMessageChangedEvent(t *testing.T) {
 	rawE := []byte(`
 		{
@@ -256,3 +256,100 @@ const inviteSharedResponseJSON = `{
     "invite_id": "I02UKAJ6RJA",
     "is_legacy_shared_channel": false
 }`
+
+var defaultConversationsListJSON = fmt.Sprintf(`
+	{
+		"ok": true,
+		"channels": [%s, %s],
+		"response_metadata": {
+			"next_cursor": ""
+		}
+	}
+	`, defaultGeneralChannelJSON, defaultExtraChannelJSON)
+
+var templateConversationHistoryJSON = `
+	{
+		"ok": true,
+		"messages": [
+			{
+				"type": "message",
+				"user": "%[1]s",
+				"text": "Hello there",
+				"ts": "1512085950.000216"
+			},
+			{
+				"type": "message",
+				"user": "%[2]s",
+				"text": "What's new?",
+				"ts": "1512085940.000215",
+				"thread_ts": "1512085940.000215",
+				"reply_count": 1,
+				"reply_users": ["%[1]s"],
+				"latest_reply": "1512085945.000216"
+			}
+		],
+		"has_more": false,
+		"response_metadata": {
+			"next_cursor": ""
+		}
+	}
+	`
+
+var templateConversationRepliesJSON = `
+	{
+		"ok": true,
+		"messages": [
+			{
+				"type": "message",
+				"user": "%[2]s",
+				"text": "What's new?",
+				"ts": "%[3]s",
+				"thread_ts": "%[3]s",
+				"reply_count": 1,
+				"reply_users": ["%[1]s"],
+				"latest_reply": "1512085945.000216"
+			},
+			{
+				"type": "message",
+				"user": "%[1]s",
+				"text": "Not much",
+				"ts": "1512085945.000216",
+				"thread_ts": "%[3]s",
+				"parent_user_id": "%[2]s"
+			}
+		],
+		"has_more": false,
+		"response_metadata": {
+			"next_cursor": ""
+		}
+	}
+	`
+
+var defaultConversationMembersJSON = fmt.Sprintf(`
+	{
+		"ok": true,
+		"members": ["%s", "%s"],
+		"response_metadata": {
+			"next_cursor": ""
+		}
+	}
+	`, defaultNonBotUserID, defaultBotID)
+
+var templateConversationOpenJSON = `
+	{
+		"ok": true,
+		"no_op": true,
+		"already_open": true,
+		"channel": {
+			"id": "%s"
+		}
+	}
+	`
+
+var templateUploadURLExternalJSON = `
+	{
+		"ok": true,
+		"upload_url": "%s",
+		"file_id": "%s"
+	}
+	`
@@ -143,8 +143,7 @@ func defaultBotInfoJSON(ctx context.Context) string {
 		`, botid, botname)
 }
 
-func defaultAppsConnectionsJSON(ctx context.Context) string {
-	url := ServerWSFromContext(ctx)
+func defaultAppsConnectionsJSON(url string) string {
 	return fmt.Sprintf(`
                {
                        "ok":true,
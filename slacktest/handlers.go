@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"sync/atomic"
 	"time"
 
 	websocket "github.com/gorilla/websocket"
@@ -125,7 +126,13 @@ func reactionAddHandler(w http.ResponseWriter, _ *http.Request) {
 
 // handle apps.connections.open
 func appsConnectionsOpenHandler(w http.ResponseWriter, r *http.Request) {
-	_, _ = w.Write([]byte(defaultAppsConnectionsJSON(r.Context())))
+	_, _ = w.Write([]byte(defaultAppsConnectionsJSON(ServerWSFromContext(r.Context()))))
+}
+
+// handle apps.connections.open when bound with BindSocketMode
+func socketModeConnectionsOpenHandler(w http.ResponseWriter, r *http.Request) {
+	serverAddr := r.Context().Value(ServerBotHubNameContextKey).(string)
+	_, _ = w.Write([]byte(defaultAppsConnectionsJSON("ws://" + serverAddr + "/socket-mode")))
 }
 
 // handle chat.postMessage
@@ -380,3 +387,107 @@ func RTMRespPong(c *websocket.Conn, m json.RawMessage) (err error) {
 
 	return nil
 }
+
+// handle conversations.list
+func listConversationsHandler(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte(defaultConversationsListJSON))
+}
+
+// handle conversations.history
+func conversationHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	_, _ = fmt.Fprintf(w, templateConversationHistoryJSON, defaultNonBotUserID, BotIDFromContext(r.Context()))
+}
+
+// handle conversations.replies
+func conversationRepliesHandler(w http.ResponseWriter, r *http.Request) {
+	ts := r.FormValue("ts")
+	if ts == "" {
+		ts = "1512085940.000215"
+	}
+	_, _ = fmt.Fprintf(w, templateConversationRepliesJSON, defaultNonBotUserID, BotIDFromContext(r.Context()), ts)
+}
+
+// handle conversations.members
+func conversationMembersHandler(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte(defaultConversationMembersJSON))
+}
+
+// handle conversations.open
+func openConversationHandler(w http.ResponseWriter, r *http.Request) {
+	channel := r.FormValue("channel")
+	if channel == "" {
+		channel = "D024BE91L"
+	}
+	_, _ = fmt.Fprintf(w, templateConversationOpenJSON, channel)
+}
+
+// handle conversations.leave, conversations.archive and the other methods only answering ok
+func okHandler(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte(defaultOkJSON))
+}
+
+// handle views.open, views.push, views.update and views.publish
+func viewHandler(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		View   map[string]interface{} `json:"view"`
+		ViewID string                 `json:"view_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		msg := fmt.Sprintf("Unable to decode view request: %s", err.Error())
+		log.Print(msg)
+		http.Error(w, msg, http.StatusBadRequest)
+		return
+	}
+	if request.View == nil {
+		request.View = map[string]interface{}{}
+	}
+
+	id := request.ViewID
+	if id == "" {
+		id = fmt.Sprintf("V%010d", time.Now().UnixNano()%1e10)
+	}
+	request.View["id"] = id
+	request.View["team_id"] = defaultTeamID
+	request.View["hash"] = fmt.Sprintf("%d.slacktest", time.Now().Unix())
+	request.View["bot_id"] = BotIDFromContext(r.Context())
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"ok":   true,
+		"view": request.View,
+	})
+}
+
+// handle files.getUploadURLExternal
+func (sts *Server) getUploadURLExternalHandler(w http.ResponseWriter, r *http.Request) {
+	fileID := fmt.Sprintf("F%010d", atomic.AddInt64(&sts.files, 1))
+	_, _ = fmt.Fprintf(w, templateUploadURLExternalJSON, sts.GetAPIURL()+"upload/"+fileID, fileID)
+}
+
+// handle the uploads to the urls returned by files.getUploadURLExternal
+func uploadHandler(w http.ResponseWriter, r *http.Request) {
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		msg := fmt.Sprintf("Unable to read uploaded file: %s", err.Error())
+		log.Print(msg)
+		http.Error(w, msg, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	size, _ := io.Copy(io.Discard, file)
+	_, _ = fmt.Fprintf(w, "OK - %d", size)
+}
+
+// handle files.completeUploadExternal
+func completeUploadExternalHandler(w http.ResponseWriter, r *http.Request) {
+	var files []slack.FileSummary
+	if err := json.Unmarshal([]byte(r.FormValue("files")), &files); err != nil {
+		_, _ = w.Write([]byte(`{"ok": false, "error": "invalid_arguments"}`))
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(slack.CompleteUploadExternalResponse{
+		SlackResponse: okWebResponse,
+		Files:         files,
+	})
+}
@@ -1,6 +1,7 @@
 package slacktest
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -123,3 +124,82 @@ func TestBotInfoHandler(t *testing.T) {
 	assert.Equal(t, s.BotName, bot.Name)
 	assert.False(t, bot.Deleted)
 }
+
+func TestServerConversationHandlers(t *testing.T) {
+	s := NewTestServer()
+	go s.Start()
+	defer s.Stop()
+
+	client := slack.New("ABCDEFG", slack.OptionAPIURL(s.GetAPIURL()))
+	channels, cursor, err := client.GetConversations(&slack.GetConversationsParameters{})
+	assert.NoError(t, err)
+	assert.Empty(t, cursor)
+	assert.Len(t, channels, 2)
+
+	history, err := client.GetConversationHistory(&slack.GetConversationHistoryParameters{ChannelID: "C024BE91L"})
+	assert.NoError(t, err)
+	assert.Len(t, history.Messages, 2)
+
+	replies, _, _, err := client.GetConversationReplies(&slack.GetConversationRepliesParameters{ChannelID: "C024BE91L", Timestamp: "1512085940.000215"})
+	assert.NoError(t, err)
+	if assert.Len(t, replies, 2) {
+		assert.Equal(t, "1512085940.000215", replies[1].ThreadTimestamp)
+	}
+
+	members, _, err := client.GetUsersInConversation(&slack.GetUsersInConversationParameters{ChannelID: "C024BE91L"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{defaultNonBotUserID, defaultBotID}, members)
+
+	channel, _, _, err := client.JoinConversation("C024BE91L")
+	assert.NoError(t, err)
+	assert.Equal(t, "C024BE91L", channel.ID)
+
+	assert.NoError(t, client.ArchiveConversation("C024BE91L"))
+	assert.NoError(t, client.KickUserFromConversation("C024BE91L", defaultNonBotUserID))
+}
+
+func TestServerViewHandlers(t *testing.T) {
+	s := NewTestServer()
+	go s.Start()
+	defer s.Stop()
+
+	client := slack.New("ABCDEFG", slack.OptionAPIURL(s.GetAPIURL()))
+	view := slack.ModalViewRequest{
+		Type:  slack.VTModal,
+		Title: slack.NewTextBlockObject(slack.PlainTextType, "Settings", false, false),
+	}
+	resp, err := client.OpenView("trigger", view)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.ID)
+	assert.Equal(t, "Settings", resp.Title.Text)
+
+	updated, err := client.UpdateView(view, "", "", resp.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, resp.ID, updated.ID)
+
+	home, err := client.PublishView(defaultNonBotUserID, slack.HomeTabViewRequest{Type: slack.VTHomeTab}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, slack.VTHomeTab, home.Type)
+}
+
+func TestServerFilesV2Handlers(t *testing.T) {
+	s := NewTestServer()
+	go s.Start()
+	defer s.Stop()
+
+	client := slack.New("ABCDEFG", slack.OptionAPIURL(s.GetAPIURL()))
+	file, err := client.UploadFileV2(slack.UploadFileV2Parameters{
+		Filename: "notes.txt",
+		Content:  "some notes",
+		FileSize: len("some notes"),
+		Title:    "Notes",
+		Channel:  "C024BE91L",
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, file.ID)
+	assert.Equal(t, "Notes", file.Title)
+
+	s.AssertSawRequest(t, "files.getUploadURLExternal", url.Values{"filename": {"notes.txt"}, "length": {"10"}})
+	s.AssertSawRequest(t, "files.completeUploadExternal", url.Values{"channel_id": {"C024BE91L"}})
+	assert.Len(t, s.GetRequests("upload/"+file.ID), 1)
+}
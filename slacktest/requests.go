@@ -0,0 +1,214 @@
+package slacktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	websocket "github.com/gorilla/websocket"
+)
+
+// ReceivedRequest is a Web API request received by the server.
+type ReceivedRequest struct {
+	// Method is the API method, e.g. chat.postMessage.
+	Method string
+	Header http.Header
+	// Values holds the query and form arguments of the request. The top-level fields of JSON
+	// bodies are added too, strings as is and other values JSON encoded.
+	Values url.Values
+	Body   []byte
+}
+
+// DecodeJSON decodes the body of a JSON request into v.
+func (r ReceivedRequest) DecodeJSON(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+type requestCollection struct {
+	sync.RWMutex
+	requests []ReceivedRequest
+}
+
+func (rc *requestCollection) observe(r ReceivedRequest) {
+	rc.Lock()
+	defer rc.Unlock()
+	rc.requests = append(rc.requests, r)
+}
+
+func (rc *requestCollection) get(method string) []ReceivedRequest {
+	rc.RLock()
+	defer rc.RUnlock()
+
+	var requests []ReceivedRequest
+	for _, r := range rc.requests {
+		if method == "" || r.Method == method {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// recordRequest reads the request, leaving its body available to the handlers.
+func recordRequest(r *http.Request) (ReceivedRequest, error) {
+	received := ReceivedRequest{
+		Method: strings.TrimPrefix(r.URL.Path, "/"),
+		Header: r.Header.Clone(),
+		Values: r.URL.Query(),
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return received, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	received.Body = body
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return received, err
+		}
+		for key, value := range values {
+			received.Values[key] = append(received.Values[key], value...)
+		}
+	case "application/json":
+		// Bodies which are not objects, e.g. empty ones, have no fields.
+		var fields map[string]json.RawMessage
+		_ = json.Unmarshal(body, &fields)
+		for key, raw := range fields {
+			var s string
+			if err := json.Unmarshal(raw, &s); err == nil {
+				received.Values.Add(key, s)
+			} else {
+				received.Values.Add(key, string(raw))
+			}
+		}
+	}
+
+	return received, nil
+}
+
+// ServeHTTP records the Web API requests, then serves them with the handler set with SetHandler
+// or SetResponse if any, or the registered one.
+func (sts *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !websocket.IsWebSocketUpgrade(r) {
+		received, err := recordRequest(r)
+		if err != nil {
+			msg := fmt.Sprintf("Unable to read request: %s", err.Error())
+			http.Error(w, msg, http.StatusBadRequest)
+			return
+		}
+		sts.requests.observe(received)
+	}
+
+	sts.overridesMu.RLock()
+	handler, ok := sts.overrides[r.URL.Path]
+	sts.overridesMu.RUnlock()
+	if ok {
+		handler.ServeHTTP(w, r)
+		return
+	}
+
+	sts.mux.ServeHTTP(w, r)
+}
+
+// SetHandler replaces the handler of an API method, e.g. conversations.history, for the
+// following requests. Unlike Handle, it can be called once the server is started and overrides
+// the default handlers.
+func (sts *Server) SetHandler(method string, handler http.HandlerFunc) {
+	sts.overridesMu.Lock()
+	defer sts.overridesMu.Unlock()
+	sts.overrides["/"+method] = contextHandler(sts, handler)
+}
+
+// SetResponse makes the server answer the following requests to an API method with response,
+// which is written as is if it is a string or a []byte, and JSON encoded otherwise.
+func (sts *Server) SetResponse(method string, response interface{}) {
+	var body []byte
+	switch response := response.(type) {
+	case string:
+		body = []byte(response)
+	case []byte:
+		body = response
+	default:
+		var err error
+		if body, err = json.Marshal(response); err != nil {
+			panic(fmt.Sprintf("slacktest: unable to encode the response of %s: %s", method, err.Error()))
+		}
+	}
+
+	sts.SetHandler(method, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+}
+
+// ResetHandler restores the handler of an API method replaced with SetHandler or SetResponse.
+func (sts *Server) ResetHandler(method string) {
+	sts.overridesMu.Lock()
+	defer sts.overridesMu.Unlock()
+	delete(sts.overrides, "/"+method)
+}
+
+// GetRequests returns the requests received for an API method, or all the Web API requests
+// if method is empty, in the order they were received.
+func (sts *Server) GetRequests(method string) []ReceivedRequest {
+	return sts.requests.get(method)
+}
+
+// SawRequest checks if a request to the API method was received with all the given values.
+func (sts *Server) SawRequest(method string, values url.Values) bool {
+	for _, r := range sts.requests.get(method) {
+		if hasValues(r.Values, values) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TestingT is the subset of testing.TB used by the assertions of the server.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// AssertSawRequest reports an error to t, listing the received requests, if no request to the
+// API method was received with all the given values.
+func (sts *Server) AssertSawRequest(t TestingT, method string, values url.Values) bool {
+	t.Helper()
+	if sts.SawRequest(method, values) {
+		return true
+	}
+
+	var received []string
+	for _, r := range sts.requests.get(method) {
+		received = append(received, r.Values.Encode())
+	}
+	t.Errorf("expected a %s request with %s, received: %v", method, values.Encode(), received)
+	return false
+}
+
+func hasValues(values, expected url.Values) bool {
+	for key, want := range expected {
+		got := values[key]
+		if len(got) < len(want) {
+			return false
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -39,6 +39,9 @@ func NewTestServer(custom ...Binder) *Server {
 		mux:                  http.NewServeMux(),
 		seenInboundMessages:  &messageCollection{},
 		seenOutboundMessages: &messageCollection{},
+		requests:             &requestCollection{},
+		socketMode:           newSocketModeHub(),
+		overrides:            map[string]http.Handler{},
 	}
 
 	for _, c := range custom {
@@ -56,14 +59,34 @@ func NewTestServer(custom ...Binder) *Server {
 	s.Handle("/conversations.rename", renameConversationHandler)
 	s.Handle("/conversations.invite", inviteConversationHandler)
 	s.Handle("/conversations.inviteShared", inviteSharedConversationHandler)
+	s.Handle("/conversations.list", listConversationsHandler)
+	s.Handle("/conversations.history", conversationHistoryHandler)
+	s.Handle("/conversations.replies", conversationRepliesHandler)
+	s.Handle("/conversations.members", conversationMembersHandler)
+	s.Handle("/conversations.join", s.conversationsInfoHandler)
+	s.Handle("/conversations.open", openConversationHandler)
+	s.Handle("/conversations.close", okHandler)
+	s.Handle("/conversations.leave", okHandler)
+	s.Handle("/conversations.kick", okHandler)
+	s.Handle("/conversations.archive", okHandler)
+	s.Handle("/conversations.unarchive", okHandler)
+	s.Handle("/conversations.mark", okHandler)
+	s.Handle("/views.open", viewHandler)
+	s.Handle("/views.push", viewHandler)
+	s.Handle("/views.update", viewHandler)
+	s.Handle("/views.publish", viewHandler)
+	s.Handle("/files.getUploadURLExternal", s.getUploadURLExternalHandler)
+	s.Handle("/upload/", uploadHandler)
+	s.Handle("/files.completeUploadExternal", completeUploadExternalHandler)
 	s.Handle("/users.info", usersInfoHandler)
 	s.Handle("/users.lookupByEmail", usersInfoHandler)
 	s.Handle("/bots.info", botsInfoHandler)
 	s.Handle("/auth.test", authTestHandler)
 	s.Handle("/reactions.add", reactionAddHandler)
 	s.Handle("/apps.connections.open", appsConnectionsOpenHandler)
+	s.Handle("/socket-mode", s.socketModeHandler)
 
-	httpserver := httptest.NewUnstartedServer(s.mux)
+	httpserver := httptest.NewUnstartedServer(s)
 	addr := httpserver.Listener.Addr().String()
 
 	s.ServerAddr = addr
@@ -3,6 +3,7 @@ package slacktest
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"testing"
 	"time"
 
@@ -174,3 +175,63 @@ func TestServerSawOutgoingMessage(t *testing.T) {
 	s.Start()
 	assert.False(t, s.SawOutgoingMessage("foo"), "should not have seen any message")
 }
+
+func TestServerSetResponse(t *testing.T) {
+	s := NewTestServer()
+	go s.Start()
+	defer s.Stop()
+
+	client := slack.New("ABCDEFG", slack.OptionAPIURL(s.GetAPIURL()))
+	s.SetResponse("auth.test", `{"ok": false, "error": "invalid_auth"}`)
+	_, err := client.AuthTest()
+	assert.EqualError(t, err, "invalid_auth")
+
+	s.SetResponse("emoji.list", map[string]interface{}{"ok": true, "emoji": map[string]string{"party": "https://example.com/party.png"}})
+	emoji, err := client.GetEmoji()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"party": "https://example.com/party.png"}, emoji)
+
+	s.ResetHandler("auth.test")
+	_, err = client.AuthTest()
+	assert.NoError(t, err)
+}
+
+func TestServerSawRequest(t *testing.T) {
+	s := NewTestServer()
+	go s.Start()
+	defer s.Stop()
+
+	client := slack.New("ABCDEFG", slack.OptionAPIURL(s.GetAPIURL()))
+	_, _, err := client.PostMessage("C024BE91L", slack.MsgOptionText("some text", false))
+	assert.NoError(t, err)
+	_, err = client.OpenView("trigger", slack.ModalViewRequest{Type: slack.VTModal})
+	assert.NoError(t, err)
+
+	assert.True(t, s.SawRequest("chat.postMessage", url.Values{"channel": {"C024BE91L"}, "text": {"some text"}}))
+	assert.False(t, s.SawRequest("chat.postMessage", url.Values{"text": {"other text"}}))
+	assert.True(t, s.SawRequest("views.open", url.Values{"trigger_id": {"trigger"}}))
+	assert.Len(t, s.GetRequests(""), 2)
+
+	var view struct {
+		View slack.ModalViewRequest `json:"view"`
+	}
+	requests := s.GetRequests("views.open")
+	if assert.Len(t, requests, 1) {
+		assert.NoError(t, requests[0].DecodeJSON(&view))
+		assert.Equal(t, slack.VTModal, view.View.Type)
+	}
+
+	recorder := &recordingT{}
+	assert.False(t, s.AssertSawRequest(recorder, "chat.postMessage", url.Values{"text": {"other text"}}))
+	assert.Len(t, recorder.errors, 1)
+}
+
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
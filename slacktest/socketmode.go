@@ -0,0 +1,229 @@
+package slacktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	websocket "github.com/gorilla/websocket"
+)
+
+const defaultAppID = "A0123456789"
+
+// SocketModeAck is an acknowledgement of an envelope received on the Socket Mode endpoint.
+type SocketModeAck struct {
+	EnvelopeID string          `json:"envelope_id"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+}
+
+type socketModeConn struct {
+	sync.Mutex
+	conn *websocket.Conn
+}
+
+func (c *socketModeConn) write(data []byte) error {
+	c.Lock()
+	defer c.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// socketModeHub tracks the Socket Mode connections of a server. As Slack does, every envelope
+// is delivered to a single connection, taking turns between them, and envelopes sent while no
+// client is connected are delivered to the next one.
+type socketModeHub struct {
+	sync.Mutex
+	conns     []*socketModeConn
+	next      int
+	pending   [][]byte
+	envelopes int
+	acks      []SocketModeAck
+}
+
+func newSocketModeHub() *socketModeHub {
+	return &socketModeHub{}
+}
+
+func (h *socketModeHub) connect(c *socketModeConn) error {
+	h.Lock()
+	defer h.Unlock()
+
+	hello, err := json.Marshal(map[string]interface{}{
+		"type":            "hello",
+		"num_connections": len(h.conns) + 1,
+		"debug_info": map[string]interface{}{
+			"host":                        "slacktest",
+			"approximate_connection_time": 18060,
+		},
+		"connection_info": map[string]string{"app_id": defaultAppID},
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.write(hello); err != nil {
+		return err
+	}
+
+	for _, data := range h.pending {
+		if err := c.write(data); err != nil {
+			return err
+		}
+	}
+	h.pending = nil
+	h.conns = append(h.conns, c)
+
+	return nil
+}
+
+func (h *socketModeHub) disconnect(c *socketModeConn) {
+	h.Lock()
+	defer h.Unlock()
+	for i, conn := range h.conns {
+		if conn == c {
+			h.conns = append(h.conns[:i], h.conns[i+1:]...)
+			return
+		}
+	}
+}
+
+// deliver sends data to one of the connections, trying the next ones if writing fails.
+func (h *socketModeHub) deliver(data []byte) {
+	h.Lock()
+	defer h.Unlock()
+
+	for range h.conns {
+		c := h.conns[h.next%len(h.conns)]
+		h.next++
+		if err := c.write(data); err != nil {
+			log.Printf("error writing to socket mode connection: %s", err.Error())
+			continue
+		}
+		return
+	}
+	h.pending = append(h.pending, data)
+}
+
+func (h *socketModeHub) broadcast(data []byte) {
+	h.Lock()
+	defer h.Unlock()
+
+	if len(h.conns) == 0 {
+		h.pending = append(h.pending, data)
+		return
+	}
+	for _, c := range h.conns {
+		if err := c.write(data); err != nil {
+			log.Printf("error writing to socket mode connection: %s", err.Error())
+		}
+	}
+}
+
+func (h *socketModeHub) nextEnvelopeID() string {
+	h.Lock()
+	defer h.Unlock()
+	h.envelopes++
+	return fmt.Sprintf("slacktest-envelope-%d", h.envelopes)
+}
+
+func (h *socketModeHub) ack(ack SocketModeAck) {
+	h.Lock()
+	defer h.Unlock()
+	h.acks = append(h.acks, ack)
+}
+
+func (h *socketModeHub) getAcks() []SocketModeAck {
+	h.Lock()
+	defer h.Unlock()
+	return append([]SocketModeAck(nil), h.acks...)
+}
+
+// handle the Socket Mode websocket
+func (sts *Server) socketModeHandler(w http.ResponseWriter, r *http.Request) {
+	Websocket(func(c *websocket.Conn) {
+		conn := &socketModeConn{conn: c}
+		if err := sts.socketMode.connect(conn); err != nil {
+			log.Printf("error opening socket mode connection: %s", err.Error())
+			return
+		}
+		defer sts.socketMode.disconnect(conn)
+
+		for {
+			var ack SocketModeAck
+			if err := c.ReadJSON(&ack); err != nil {
+				if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					log.Printf("socket mode read error: %s", err.Error())
+				}
+				return
+			}
+			sts.socketMode.ack(ack)
+		}
+	})(w, r)
+}
+
+// BindSocketMode makes apps.connections.open return the Socket Mode websocket url of the
+// server, so that socketmode clients connect to it, instead of the RTM websocket url it returns
+// by default.
+func BindSocketMode(c Customize) {
+	c.Handle("/apps.connections.open", socketModeConnectionsOpenHandler)
+}
+
+// GetSocketModeURL returns the Socket Mode websocket url, which apps.connections.open returns
+// when the server is bound with BindSocketMode
+func (sts *Server) GetSocketModeURL() string {
+	return "ws://" + sts.ServerAddr + "/socket-mode"
+}
+
+// SendSocketModeEnvelope sends a payload of the given type, e.g. events_api, interactive or
+// slash_commands, to one of the connected Socket Mode clients, and returns the ID of its
+// envelope. If no client is connected, the envelope is sent to the next one.
+func (sts *Server) SendSocketModeEnvelope(envelopeType string, payload interface{}) (string, error) {
+	envelopeID := sts.socketMode.nextEnvelopeID()
+	data, err := json.Marshal(map[string]interface{}{
+		"envelope_id":              envelopeID,
+		"type":                     envelopeType,
+		"payload":                  payload,
+		"accepts_response_payload": envelopeType != "events_api",
+		"retry_attempt":            0,
+		"retry_reason":             "",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sts.socketMode.deliver(data)
+	return envelopeID, nil
+}
+
+// SendSocketModeDisconnect asks the connected Socket Mode clients to reconnect.
+func (sts *Server) SendSocketModeDisconnect(reason string) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"type":   "disconnect",
+		"reason": reason,
+		"debug_info": map[string]string{
+			"host": "slacktest",
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	sts.socketMode.broadcast(data)
+	return nil
+}
+
+// GetSocketModeAcks returns the acknowledgements received from Socket Mode clients
+func (sts *Server) GetSocketModeAcks() []SocketModeAck {
+	return sts.socketMode.getAcks()
+}
+
+// SawSocketModeAck checks if the envelope with the given ID was acknowledged
+func (sts *Server) SawSocketModeAck(envelopeID string) bool {
+	for _, ack := range sts.socketMode.getAcks() {
+		if ack.EnvelopeID == envelopeID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,119 @@
+package slacktest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+)
+
+func TestServerSocketMode(t *testing.T) {
+	s := NewTestServer(BindSocketMode)
+	go s.Start()
+	defer s.Stop()
+
+	// Envelopes sent before the client connects are delivered once it does.
+	envelopeID, err := s.SendSocketModeEnvelope("events_api", map[string]interface{}{
+		"type":  "event_callback",
+		"event": map[string]string{"type": "app_mention", "text": "hi"},
+	})
+	require.NoError(t, err)
+
+	api := slack.New("xoxb-token", slack.OptionAPIURL(s.GetAPIURL()), slack.OptionAppLevelToken("xapp-token"))
+	client := socketmode.New(api)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go client.RunContext(ctx)
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case evt := <-client.Events:
+			if evt.Type != socketmode.EventTypeEventsAPI {
+				continue
+			}
+			assert.Equal(t, envelopeID, evt.Request.EnvelopeID)
+			client.Ack(*evt.Request, map[string]string{"status": "done"})
+
+			assert.Eventually(t, func() bool { return s.SawSocketModeAck(envelopeID) }, 5*time.Second, 10*time.Millisecond)
+			acks := s.GetSocketModeAcks()
+			require.Len(t, acks, 1)
+			var payload map[string]string
+			require.NoError(t, json.Unmarshal(acks[0].Payload, &payload))
+			assert.Equal(t, "done", payload["status"])
+			return
+		case <-timeout:
+			t.Fatal("timed out waiting for the events_api envelope")
+		}
+	}
+}
+
+func TestServerSocketModeSingleDelivery(t *testing.T) {
+	s := NewTestServer(BindSocketMode)
+	go s.Start()
+	defer s.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan string, 10)
+	for i := 0; i < 2; i++ {
+		api := slack.New("xoxb-token", slack.OptionAPIURL(s.GetAPIURL()), slack.OptionAppLevelToken("xapp-token"))
+		client := socketmode.New(api)
+		go client.RunContext(ctx)
+		go func() {
+			for evt := range client.Events {
+				if evt.Type == socketmode.EventTypeEventsAPI {
+					received <- evt.Request.EnvelopeID
+				}
+			}
+		}()
+	}
+	require.Eventually(t, func() bool {
+		s.socketMode.Lock()
+		defer s.socketMode.Unlock()
+		return len(s.socketMode.conns) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var sent []string
+	for i := 0; i < 4; i++ {
+		envelopeID, err := s.SendSocketModeEnvelope("events_api", map[string]interface{}{
+			"type":  "event_callback",
+			"event": map[string]string{"type": "app_mention", "text": "hi"},
+		})
+		require.NoError(t, err)
+		sent = append(sent, envelopeID)
+	}
+
+	var got []string
+	for len(got) < len(sent) {
+		select {
+		case envelopeID := <-received:
+			got = append(got, envelopeID)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the envelopes")
+		}
+	}
+	assert.ElementsMatch(t, sent, got)
+	select {
+	case envelopeID := <-received:
+		t.Fatalf("envelope %s was delivered twice", envelopeID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestServerAppsConnectionsOpen(t *testing.T) {
+	s := NewTestServer()
+	go s.Start()
+	defer s.Stop()
+
+	api := slack.New("xoxb-token", slack.OptionAPIURL(s.GetAPIURL()), slack.OptionAppLevelToken("xapp-token"))
+	_, url, err := api.StartSocketModeContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, s.GetWSURL(), url, "the RTM websocket is returned unless bound with BindSocketMode")
+}
@@ -87,6 +87,12 @@ type Server struct {
 	groups               *serverGroups
 	seenInboundMessages  *messageCollection
 	seenOutboundMessages *messageCollection
+	requests             *requestCollection
+	socketMode           *socketModeHub
+	files                int64
+
+	overridesMu sync.RWMutex
+	overrides   map[string]http.Handler
 }
 
 type fullInfoSlackResponse struct {
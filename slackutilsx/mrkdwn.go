@@ -0,0 +1,173 @@
+package slackutilsx
+
+import (
+	"regexp"
+	"strings"
+)
+
+var unescapeReplacer = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+
+// UnescapeMessage reverts EscapeMessage.
+func UnescapeMessage(message string) string {
+	return unescapeReplacer.Replace(message)
+}
+
+// idPattern matches the IDs of Slack users, conversations and user groups.
+var idPattern = regexp.MustCompile(`^[A-Z0-9]+$`)
+
+// idToken returns the mrkdwn token made of prefix and id. If id is not a valid Slack ID the
+// token is escaped, so that user provided values cannot inject mentions or other links.
+func idToken(prefix, id string) string {
+	token := "<" + prefix + id + ">"
+	if !idPattern.MatchString(id) {
+		return EscapeMessage(token)
+	}
+
+	return token
+}
+
+// Mention returns the mrkdwn mention of a user. An invalid user ID is rendered as plain text.
+func Mention(userID string) string {
+	return idToken("@", userID)
+}
+
+// ChannelLink returns the mrkdwn link to a conversation. An invalid conversation ID is rendered
+// as plain text.
+func ChannelLink(channelID string) string {
+	return idToken("#", channelID)
+}
+
+// UserGroupMention returns the mrkdwn mention of a user group. An invalid user group ID is
+// rendered as plain text.
+func UserGroupMention(userGroupID string) string {
+	return idToken("!subteam^", userGroupID)
+}
+
+// Link returns a mrkdwn link to url, displayed as text if it is not empty. Both are escaped,
+// so that user provided values cannot inject mentions or other links.
+func Link(url, text string) string {
+	url = strings.ReplaceAll(EscapeMessage(url), "|", "%7C")
+	if text == "" {
+		return "<" + url + ">"
+	}
+
+	return "<" + url + "|" + EscapeMessage(text) + ">"
+}
+
+// TokenType is the type of a Token.
+type TokenType int
+
+const (
+	// TokenText is plain text.
+	TokenText TokenType = iota
+	// TokenUserMention is a user mention, e.g. <@U123>.
+	TokenUserMention
+	// TokenChannel is a link to a conversation, e.g. <#C123|general>.
+	TokenChannel
+	// TokenUserGroup is a user group mention, e.g. <!subteam^S123>.
+	TokenUserGroup
+	// TokenBroadcast is a <!here>, <!channel> or <!everyone> mention.
+	TokenBroadcast
+	// TokenSpecial is any other special command, e.g. <!date^1392734382^{date}|Feb 18, 2014>.
+	TokenSpecial
+	// TokenURL is a link, e.g. <https://example.com|Example>.
+	TokenURL
+	// TokenEmoji is an emoji short-code, e.g. :tada:.
+	TokenEmoji
+)
+
+func (t TokenType) String() string {
+	switch t {
+	case TokenText:
+		return "Text"
+	case TokenUserMention:
+		return "UserMention"
+	case TokenChannel:
+		return "Channel"
+	case TokenUserGroup:
+		return "UserGroup"
+	case TokenBroadcast:
+		return "Broadcast"
+	case TokenSpecial:
+		return "Special"
+	case TokenURL:
+		return "URL"
+	case TokenEmoji:
+		return "Emoji"
+	default:
+		return "Unknown"
+	}
+}
+
+// Token is a part of a mrkdwn message.
+type Token struct {
+	Type TokenType
+	// Raw is the token as found in the message.
+	Raw string
+	// Text is the unescaped text of TokenText tokens, or the label of the other tokens, if any.
+	Text string
+	// ID is the ID of the mentioned user, conversation or user group, the range of a broadcast,
+	// the command of a special token or the name of an emoji, without skin tone.
+	ID string
+	// URL is the address of TokenURL tokens.
+	URL string
+}
+
+var (
+	entityPattern = regexp.MustCompile(`<([^<>|]+)(?:\|([^<>]*))?>`)
+	emojiPattern  = regexp.MustCompile(`:([a-z0-9_'+-]*[a-z][a-z0-9_'+-]*|[+-]1)(?:::skin-tone-[2-6])?:`)
+)
+
+// Tokenize splits mrkdwn text into plain text, mentions, links and emoji.
+func Tokenize(text string) []Token {
+	var tokens []Token
+
+	last := 0
+	for _, m := range entityPattern.FindAllStringSubmatchIndex(text, -1) {
+		tokens = appendTextTokens(tokens, text[last:m[0]])
+
+		token := Token{Raw: text[m[0]:m[1]]}
+		target := text[m[2]:m[3]]
+		if m[4] >= 0 {
+			token.Text = UnescapeMessage(text[m[4]:m[5]])
+		}
+
+		switch {
+		case strings.HasPrefix(target, "@"):
+			token.Type, token.ID = TokenUserMention, target[1:]
+		case strings.HasPrefix(target, "#"):
+			token.Type, token.ID = TokenChannel, target[1:]
+		case strings.HasPrefix(target, "!subteam^"):
+			token.Type, token.ID = TokenUserGroup, strings.TrimPrefix(target, "!subteam^")
+		case target == "!here" || target == "!channel" || target == "!everyone":
+			token.Type, token.ID = TokenBroadcast, target[1:]
+		case strings.HasPrefix(target, "!"):
+			token.Type, token.ID = TokenSpecial, target[1:]
+		default:
+			token.Type, token.URL = TokenURL, UnescapeMessage(target)
+		}
+		tokens = append(tokens, token)
+
+		last = m[1]
+	}
+
+	return appendTextTokens(tokens, text[last:])
+}
+
+// appendTextTokens splits text outside of entities into plain text and emoji.
+func appendTextTokens(tokens []Token, text string) []Token {
+	last := 0
+	for _, m := range emojiPattern.FindAllStringSubmatchIndex(text, -1) {
+		if m[0] > last {
+			tokens = append(tokens, Token{Type: TokenText, Raw: text[last:m[0]], Text: UnescapeMessage(text[last:m[0]])})
+		}
+		tokens = append(tokens, Token{Type: TokenEmoji, Raw: text[m[0]:m[1]], ID: text[m[2]:m[3]]})
+		last = m[1]
+	}
+
+	if last < len(text) {
+		tokens = append(tokens, Token{Type: TokenText, Raw: text[last:], Text: UnescapeMessage(text[last:])})
+	}
+
+	return tokens
+}
@@ -0,0 +1,71 @@
+package slackutilsx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMrkdwnBuilders(t *testing.T) {
+	test := func(computed, expected string) {
+		if computed != expected {
+			t.Errorf("expected %s, got: %s", expected, computed)
+		}
+	}
+
+	test(Mention("U123"), "<@U123>")
+	test(ChannelLink("C123"), "<#C123>")
+	test(UserGroupMention("S123"), "<!subteam^S123>")
+	test(Mention("U1><!channel"), "&lt;@U1&gt;&lt;!channel&gt;")
+	test(ChannelLink("C1|<!here>"), "&lt;#C1|&lt;!here&gt;&gt;")
+	test(UserGroupMention(""), "&lt;!subteam^&gt;")
+	test(Link("https://example.com/?a=1&b=2", ""), "<https://example.com/?a=1&amp;b=2>")
+	test(Link("https://example.com/a|b", "<!channel> & co"), "<https://example.com/a%7Cb|&lt;!channel&gt; &amp; co>")
+	test(UnescapeMessage(EscapeMessage("<@U123> & <!here>")), "<@U123> & <!here>")
+}
+
+func TestTokenize(t *testing.T) {
+	text := "Hi <@U1>, <@U2|bob> see <#C1|general> &amp; <https://example.com/?a=1&amp;b=2|the docs> " +
+		":+1::skin-tone-2: :tada: at 12:30:45 <!here> <!subteam^S1|@oncall> <!date^1392734382^{date}|Feb 18> &lt;x&gt;"
+
+	expected := []Token{
+		{Type: TokenText, Raw: "Hi ", Text: "Hi "},
+		{Type: TokenUserMention, Raw: "<@U1>", ID: "U1"},
+		{Type: TokenText, Raw: ", ", Text: ", "},
+		{Type: TokenUserMention, Raw: "<@U2|bob>", ID: "U2", Text: "bob"},
+		{Type: TokenText, Raw: " see ", Text: " see "},
+		{Type: TokenChannel, Raw: "<#C1|general>", ID: "C1", Text: "general"},
+		{Type: TokenText, Raw: " &amp; ", Text: " & "},
+		{Type: TokenURL, Raw: "<https://example.com/?a=1&amp;b=2|the docs>", URL: "https://example.com/?a=1&b=2", Text: "the docs"},
+		{Type: TokenText, Raw: " ", Text: " "},
+		{Type: TokenEmoji, Raw: ":+1::skin-tone-2:", ID: "+1"},
+		{Type: TokenText, Raw: " ", Text: " "},
+		{Type: TokenEmoji, Raw: ":tada:", ID: "tada"},
+		{Type: TokenText, Raw: " at 12:30:45 ", Text: " at 12:30:45 "},
+		{Type: TokenBroadcast, Raw: "<!here>", ID: "here"},
+		{Type: TokenText, Raw: " ", Text: " "},
+		{Type: TokenUserGroup, Raw: "<!subteam^S1|@oncall>", ID: "S1", Text: "@oncall"},
+		{Type: TokenText, Raw: " ", Text: " "},
+		{Type: TokenSpecial, Raw: "<!date^1392734382^{date}|Feb 18>", ID: "date^1392734382^{date}", Text: "Feb 18"},
+		{Type: TokenText, Raw: " &lt;x&gt;", Text: " <x>"},
+	}
+
+	tokens := Tokenize(text)
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("unexpected tokens:\n%+v\nexpected:\n%+v", tokens, expected)
+	}
+
+	var raw string
+	for _, token := range tokens {
+		raw += token.Raw
+	}
+	if raw != text {
+		t.Errorf("expected raw tokens to rebuild %q, got: %q", text, raw)
+	}
+}
+
+func TestTokenizeEscapedUserInput(t *testing.T) {
+	tokens := Tokenize("hello " + EscapeMessage("<!channel> <@U1>"))
+	if len(tokens) != 1 || tokens[0].Type != TokenText || tokens[0].Text != "hello <!channel> <@U1>" {
+		t.Errorf("expected escaped input to be a single text token, got: %+v", tokens)
+	}
+}
@@ -53,6 +53,7 @@ type Client struct {
 	// Connection life-cycle
 	Events              chan Event
 	socketModeResponses chan *Response
+	socketModeEnvelopes chan *outboundEnvelope
 
 	// dialer is a gorilla/websocket Dialer. If nil, use the default
 	// Dialer.
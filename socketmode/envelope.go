@@ -0,0 +1,105 @@
+package socketmode
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Envelope is an arbitrary outbound Socket Mode frame.
+//
+// Slack occasionally adds new kinds of frames to the Socket Mode protocol before this library
+// supports them. Envelope, together with Client.SendEnvelopeCtx, lets advanced users send such
+// frames without giving up the write serialization the connection requires.
+type Envelope struct {
+	Type       string      `json:"type,omitempty"`
+	EnvelopeID string      `json:"envelope_id,omitempty"`
+	Payload    interface{} `json:"payload,omitempty"`
+}
+
+// Send states of an outboundEnvelope.
+const (
+	envelopePending int32 = iota
+	envelopeWriting
+	envelopeCanceled
+)
+
+// outboundEnvelope is an encoded frame waiting to be written by runResponseSender.
+type outboundEnvelope struct {
+	data   json.RawMessage
+	result chan error
+	// state is switched from envelopePending either by the writer, before writing the frame,
+	// or by the sender giving up on it, so that a frame is never written once its send failed.
+	state int32
+}
+
+// claim reports whether the frame may still be written, and prevents it from being canceled.
+func (env *outboundEnvelope) claim() bool {
+	return atomic.CompareAndSwapInt32(&env.state, envelopePending, envelopeWriting)
+}
+
+// cancel reports whether the frame was canceled before being written.
+func (env *outboundEnvelope) cancel() bool {
+	return atomic.CompareAndSwapInt32(&env.state, envelopePending, envelopeCanceled)
+}
+
+// SendEnvelope sends an arbitrary frame over the WebSocket connection.
+// For more details, see SendEnvelopeCtx documentation.
+func (smc *Client) SendEnvelope(envelope interface{}) error {
+	return smc.SendEnvelopeCtx(context.Background(), envelope)
+}
+
+// SendEnvelopeCtx encodes envelope as JSON and sends it over the WebSocket connection, through the
+// same writer as the acknowledgements. envelope is usually an Envelope, but any value encoding to a
+// JSON object is accepted.
+//
+// Unlike SendCtx, SendEnvelopeCtx blocks until the frame has been written and returns the write
+// error, if any. When the outbound queue is full, or while the client is reconnecting, it waits
+// for the writer to catch up or for the context to be done, whichever happens first. The context
+// error is only returned if the frame will not be written; once the writer has started writing
+// it, SendEnvelopeCtx waits for the write to complete.
+func (smc *Client) SendEnvelopeCtx(ctx context.Context, envelope interface{}) error {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	smc.Debugf("Scheduling Socket Mode envelope: %s", data)
+
+	env := &outboundEnvelope{
+		data:   data,
+		result: make(chan error, 1),
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case smc.socketModeEnvelopes <- env:
+	}
+
+	select {
+	case <-ctx.Done():
+		if env.cancel() {
+			return ctx.Err()
+		}
+		return <-env.result
+	case err := <-env.result:
+		return err
+	}
+}
+
+// unsafeWriteSocketModeEnvelope sends an encoded frame to Slack.
+// WARNING: Call to this function must be serialized, see unsafeWriteSocketModeResponse.
+func unsafeWriteSocketModeEnvelope(conn *websocket.Conn, env *outboundEnvelope) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		return err
+	}
+
+	// Remove write deadline regardless of WriteMessage succeeds or not
+	defer conn.SetWriteDeadline(time.Time{})
+
+	return conn.WriteMessage(websocket.TextMessage, env.data)
+}
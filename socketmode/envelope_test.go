@@ -0,0 +1,82 @@
+package socketmode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slack-go/slack"
+)
+
+func TestSendEnvelopeCtx(t *testing.T) {
+	frames := make(chan string, 10)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			frames <- string(data)
+		}
+	}))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	cli := New(slack.New("xapp-test"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// A frame whose send failed is queued but must never be written.
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer timeoutCancel()
+	err = cli.SendEnvelopeCtx(timeoutCtx, Envelope{Type: "canceled"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, cli.socketModeEnvelopes, 1)
+
+	go cli.runResponseSender(ctx, conn)
+
+	err = cli.SendEnvelopeCtx(ctx, Envelope{Type: "custom", Payload: map[string]string{"hello": "world"}})
+	require.NoError(t, err)
+	require.NoError(t, cli.AckCtx(ctx, "1", nil))
+
+	assert.JSONEq(t, `{"type":"custom","payload":{"hello":"world"}}`, <-frames)
+	assert.JSONEq(t, `{"envelope_id":"1"}`, <-frames)
+	assert.Empty(t, frames)
+}
+
+func TestSendEnvelopeCtxBackpressure(t *testing.T) {
+	cli := New(slack.New("xapp-test"))
+
+	// Without a connection nothing drains the queue, so sending has to give up once the
+	// context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := cli.SendEnvelopeCtx(ctx, Envelope{Type: "custom"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSendEnvelopeCtxInvalidEnvelope(t *testing.T) {
+	cli := New(slack.New("xapp-test"))
+
+	err := cli.SendEnvelopeCtx(context.Background(), Envelope{Payload: make(chan int)})
+	assert.Error(t, err)
+	assert.Empty(t, cli.socketModeEnvelopes)
+}
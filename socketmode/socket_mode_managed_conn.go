@@ -302,11 +302,7 @@ func (smc *Client) openAndDial(ctx context.Context, additionalPingHandler func(s
 	// Only use HTTPS for connections to prevent MITM attacks on the connection.
 	upgradeHeader := http.Header{}
 	upgradeHeader.Add("Origin", "https://api.slack.com")
-	dialer := websocket.DefaultDialer
-	if smc.dialer != nil {
-		dialer = smc.dialer
-	}
-	conn, _, err := dialer.DialContext(ctx, url, upgradeHeader)
+	conn, _, err := smc.DialWebsocketContext(ctx, smc.dialer, url, upgradeHeader)
 	if err != nil {
 		smc.Debugf("Failed to dial to the websocket: %s", err)
 		return nil, nil, err
@@ -335,8 +331,8 @@ func (smc *Client) openAndDial(ctx context.Context, additionalPingHandler func(s
 	return info, conn, err
 }
 
-// runResponseSender runs the handler that reads Socket Mode responses enqueued onto Client.socketModeResponses channel
-// and sends them one by one over the WebSocket connection.
+// runResponseSender runs the handler that reads Socket Mode responses enqueued onto Client.socketModeResponses channel,
+// as well as the envelopes enqueued onto Client.socketModeEnvelopes, and sends them one by one over the WebSocket connection.
 // Gorilla WebSocket is not goroutine safe hence this needs to be the single place you write to the WebSocket connection.
 func (smc *Client) runResponseSender(ctx context.Context, conn *websocket.Conn) error {
 	for {
@@ -355,6 +351,16 @@ func (smc *Client) runResponseSender(ctx context.Context, conn *websocket.Conn)
 			}
 
 			smc.Debugf("Finished sending Socket Mode response with envelope ID %q", res.EnvelopeID)
+		// 4. listen for custom envelopes that need to be sent
+		case env := <-smc.socketModeEnvelopes:
+			if !env.claim() {
+				smc.Debugf("Skipping canceled Socket Mode envelope: %s", env.data)
+				continue
+			}
+
+			smc.Debugf("Sending Socket Mode envelope: %s", env.data)
+
+			env.result <- unsafeWriteSocketModeEnvelope(conn, env)
 		}
 	}
 }
@@ -109,6 +109,7 @@ func New(api *slack.Client, options ...Option) *Client {
 		Client:              *api,
 		Events:              make(chan Event, 50),
 		socketModeResponses: make(chan *Response, 20),
+		socketModeEnvelopes: make(chan *outboundEnvelope, 20),
 		maxPingInterval:     defaultMaxPingInterval,
 		log:                 log.New(os.Stderr, "slack-go/slack/socketmode", log.LstdFlags|log.Lshortfile),
 	}
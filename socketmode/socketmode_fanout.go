@@ -0,0 +1,223 @@
+package socketmode
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+const defaultSubscriptionBufferSize = 50
+
+// Fanout tees the events received by a single Client to any number of
+// independent subscribers.
+//
+// Every subscription has its own buffered queue, so a slow or misbehaving
+// subscriber never blocks the others: once its queue is full, further events
+// for that subscriber are dropped, logged and counted, see Subscription.Dropped.
+// Handlers registered with SubscribeFunc run on their own goroutine and are
+// shielded from each other's panics.
+//
+// As the same envelope may reach several subscribers, the Fanout owns the
+// acknowledgement of events_api envelopes: they are acked before being
+// dispatched, and subscribers must not ack them again. Interactive and slash
+// command envelopes can be acked with a response payload, so acking them is
+// left to the single subscriber expected to handle each of them. Those that
+// reach no subscriber, because none matches or all matching queues are full,
+// are acked by the Fanout without a payload so that Slack does not deliver them
+// again.
+type Fanout struct {
+	Client *Client
+
+	mu     sync.Mutex
+	subs   []*Subscription
+	closed bool
+}
+
+// Subscription is a single consumer of a Fanout.
+type Subscription struct {
+	// Name identifies the subscription in log messages.
+	Name string
+
+	// Events receives every event matching the subscription. It is closed
+	// when the subscription is removed or the Fanout event loop stops.
+	Events <-chan Event
+
+	events  chan Event
+	types   map[EventType]struct{}
+	dropped uint64
+	once    sync.Once
+}
+
+// Dropped returns the number of events that were discarded because the
+// subscription queue was full.
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+func (s *Subscription) matches(evt Event) bool {
+	if len(s.types) == 0 {
+		return true
+	}
+	_, ok := s.types[evt.Type]
+	return ok
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() { close(s.events) })
+}
+
+// NewFanout returns a Fanout reading events from the given client.
+func NewFanout(client *Client) *Fanout {
+	return &Fanout{Client: client}
+}
+
+// Subscribe registers a new subscription receiving the events of the given types,
+// or every event when no type is specified. A bufferSize lower than 1 uses the
+// default queue size.
+func (f *Fanout) Subscribe(name string, bufferSize int, types ...EventType) *Subscription {
+	if bufferSize < 1 {
+		bufferSize = defaultSubscriptionBufferSize
+	}
+
+	events := make(chan Event, bufferSize)
+	sub := &Subscription{
+		Name:   name,
+		Events: events,
+		events: events,
+		types:  make(map[EventType]struct{}, len(types)),
+	}
+	for _, t := range types {
+		sub.types[t] = struct{}{}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		sub.close()
+		return sub
+	}
+	f.subs = append(f.subs, sub)
+
+	return sub
+}
+
+// SubscribeFunc registers a subscription whose events are passed, one at a time and
+// in order, to handler on a dedicated goroutine. A panic in handler is recovered and
+// logged so that it does not affect the other subscriptions.
+func (f *Fanout) SubscribeFunc(name string, bufferSize int, handler SocketmodeHandlerFunc, types ...EventType) *Subscription {
+	if handler == nil {
+		panic("invalid handler cannot be nil")
+	}
+
+	sub := f.Subscribe(name, bufferSize, types...)
+	go func() {
+		for evt := range sub.Events {
+			f.invoke(sub, handler, evt)
+		}
+	}()
+
+	return sub
+}
+
+func (f *Fanout) invoke(sub *Subscription, handler SocketmodeHandlerFunc, evt Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			f.Client.log.Printf("subscription %q panicked handling %s event: %v\n", sub.Name, evt.Type, r)
+		}
+	}()
+
+	handler(&evt, f.Client)
+}
+
+// Unsubscribe removes the subscription and closes its Events channel.
+func (f *Fanout) Unsubscribe(sub *Subscription) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i, s := range f.subs {
+		if s == sub {
+			f.subs = append(f.subs[:i], f.subs[i+1:]...)
+			break
+		}
+	}
+	sub.close()
+}
+
+// RunEventLoop starts fanning out events and runs the underlying client.
+func (f *Fanout) RunEventLoop() error {
+	return f.RunEventLoopContext(context.Background())
+}
+
+// RunEventLoopContext starts fanning out events and runs the underlying client
+// until the context is cancelled.
+func (f *Fanout) RunEventLoopContext(ctx context.Context) error {
+	go f.runEventLoop(ctx)
+
+	return f.Client.RunContext(ctx)
+}
+
+func (f *Fanout) runEventLoop(ctx context.Context) {
+	defer f.closeAll()
+
+	for {
+		select {
+		case evt, ok := <-f.Client.Events:
+			if !ok {
+				return
+			}
+
+			if evt.Type == EventTypeEventsAPI && evt.Request != nil {
+				f.ack(ctx, evt)
+			}
+
+			if !f.dispatch(evt) && evt.Request != nil && evt.Type != EventTypeEventsAPI {
+				f.ack(ctx, evt)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (f *Fanout) ack(ctx context.Context, evt Event) {
+	if err := f.Client.AckCtx(ctx, evt.Request.EnvelopeID, nil); err != nil {
+		f.Client.log.Printf("failed to ack %s envelope %s: %v\n", evt.Type, evt.Request.EnvelopeID, err)
+	}
+}
+
+// dispatch hands evt to every matching subscription without blocking, and
+// reports whether at least one of them received it.
+func (f *Fanout) dispatch(evt Event) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delivered := false
+	for _, sub := range f.subs {
+		if !sub.matches(evt) {
+			continue
+		}
+
+		select {
+		case sub.events <- evt:
+			delivered = true
+		default:
+			atomic.AddUint64(&sub.dropped, 1)
+			f.Client.log.Printf("subscription %q queue is full, dropping %s event\n", sub.Name, evt.Type)
+		}
+	}
+
+	return delivered
+}
+
+func (f *Fanout) closeAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, sub := range f.subs {
+		sub.close()
+	}
+	f.subs = nil
+	f.closed = true
+}
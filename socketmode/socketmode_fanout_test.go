@@ -0,0 +1,131 @@
+package socketmode
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFanoutTestClient() *Client {
+	return &Client{
+		Events:              make(chan Event, 10),
+		socketModeResponses: make(chan *Response, 10),
+		log:                 log.New(io.Discard, "", 0),
+	}
+}
+
+func receive(t *testing.T, ch <-chan Event) Event {
+	t.Helper()
+	select {
+	case evt := <-ch:
+		return evt
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return Event{}
+}
+
+func TestFanout_Subscribe(t *testing.T) {
+	client := newFanoutTestClient()
+	fanout := NewFanout(client)
+
+	all := fanout.Subscribe("all", 10)
+	hello := fanout.Subscribe("hello", 10, EventTypeHello)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go fanout.runEventLoop(ctx)
+
+	client.Events <- Event{Type: EventTypeConnecting}
+	client.Events <- Event{Type: EventTypeHello}
+
+	assert.Equal(t, EventTypeConnecting, receive(t, all.Events).Type)
+	assert.Equal(t, EventTypeHello, receive(t, all.Events).Type)
+	assert.Equal(t, EventTypeHello, receive(t, hello.Events).Type)
+
+	cancel()
+	_, ok := <-hello.Events
+	assert.False(t, ok, "subscription should be closed when the event loop stops")
+}
+
+func TestFanout_SlowSubscriberDoesNotBlockOthers(t *testing.T) {
+	client := newFanoutTestClient()
+	fanout := NewFanout(client)
+
+	slow := fanout.Subscribe("slow", 1)
+	fast := fanout.Subscribe("fast", 10)
+
+	for i := 0; i < 3; i++ {
+		fanout.dispatch(Event{Type: EventTypeHello})
+	}
+
+	assert.Len(t, fast.Events, 3)
+	assert.Len(t, slow.Events, 1)
+	assert.Equal(t, uint64(2), slow.Dropped())
+	assert.Equal(t, uint64(0), fast.Dropped())
+}
+
+func TestFanout_SubscribeFuncRecoversPanics(t *testing.T) {
+	client := newFanoutTestClient()
+	fanout := NewFanout(client)
+
+	received := make(chan Event, 2)
+	fanout.SubscribeFunc("panics", 10, func(*Event, *Client) { panic("boom") })
+	fanout.SubscribeFunc("works", 10, func(evt *Event, _ *Client) { received <- *evt })
+
+	fanout.dispatch(Event{Type: EventTypeHello})
+	fanout.dispatch(Event{Type: EventTypeDisconnect})
+
+	assert.Equal(t, EventTypeHello, receive(t, received).Type)
+	assert.Equal(t, EventTypeDisconnect, receive(t, received).Type)
+}
+
+func TestFanout_Unsubscribe(t *testing.T) {
+	fanout := NewFanout(newFanoutTestClient())
+
+	sub := fanout.Subscribe("sub", 10)
+	fanout.Unsubscribe(sub)
+	fanout.dispatch(Event{Type: EventTypeHello})
+
+	_, ok := <-sub.Events
+	require.False(t, ok)
+}
+
+func TestFanout_Acks(t *testing.T) {
+	client := newFanoutTestClient()
+	fanout := NewFanout(client)
+
+	events := fanout.Subscribe("events", 10, EventTypeEventsAPI)
+	also := fanout.Subscribe("also", 10, EventTypeEventsAPI)
+	full := fanout.Subscribe("full", 1, EventTypeInteractive)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go fanout.runEventLoop(ctx)
+
+	client.Events <- Event{Type: EventTypeEventsAPI, Request: &Request{EnvelopeID: "e1"}}
+	client.Events <- Event{Type: EventTypeInteractive, Request: &Request{EnvelopeID: "i1"}}
+	client.Events <- Event{Type: EventTypeInteractive, Request: &Request{EnvelopeID: "i2"}}
+	client.Events <- Event{Type: EventTypeSlashCommand, Request: &Request{EnvelopeID: "s1"}}
+
+	var acked []string
+	for i := 0; i < 3; i++ {
+		select {
+		case res := <-client.socketModeResponses:
+			acked = append(acked, res.EnvelopeID)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for ack")
+		}
+	}
+	// i1 was delivered, its subscriber is in charge of acking it.
+	assert.Equal(t, []string{"e1", "i2", "s1"}, acked)
+	assert.Equal(t, "e1", receive(t, events.Events).Request.EnvelopeID)
+	assert.Equal(t, "e1", receive(t, also.Events).Request.EnvelopeID)
+	assert.Equal(t, "i1", receive(t, full.Events).Request.EnvelopeID)
+	assert.Equal(t, uint64(1), full.Dropped())
+}
@@ -175,6 +175,7 @@ type listResponsePaginated struct {
 // StarredItemPagination allows for paginating over the starred items
 type StarredItemPagination struct {
 	Items        []Item
+	PageInfo     PageInfo
 	limit        int
 	previousResp *ResponseMetadata
 	c            *Client
@@ -264,6 +265,8 @@ func (t StarredItemPagination) next(ctx context.Context) (_ StarredItemPaginatio
 
 	t.previousResp = &resp.Metadata
 	t.Items = resp.Items
+	t.PageInfo = newPageInfo(t.limit, 0, len(resp.Items), resp.Metadata.Cursor, false, pageWarnings(resp.Warning, resp.Metadata.Warnings))
+	t.c.debugPageInfo("stars.list", t.PageInfo)
 
 	return t, nil
 }
@@ -26,3 +26,24 @@ func (t StatusCodeError) Retryable() bool {
 	}
 	return false
 }
+
+// Warnings implements APIError. Slack sends no details along with HTTP errors,
+// so this and the other detail accessors of StatusCodeError always return nil.
+func (t StatusCodeError) Warnings() []string {
+	return nil
+}
+
+// Messages implements APIError.
+func (t StatusCodeError) Messages() []string {
+	return nil
+}
+
+// NeededScopes implements APIError.
+func (t StatusCodeError) NeededScopes() []string {
+	return nil
+}
+
+// ProvidedScopes implements APIError.
+func (t StatusCodeError) ProvidedScopes() []string {
+	return nil
+}
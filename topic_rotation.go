@@ -0,0 +1,185 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TopicProvider computes the topic that should be applied to a conversation at the given time,
+// e.g. the name of the person on call for the week.
+type TopicProvider func(ctx context.Context, at time.Time) (string, error)
+
+// TopicSchedule returns the first time strictly after the given time at which the topic should
+// be rotated.
+type TopicSchedule func(after time.Time) time.Time
+
+// IntervalTopicSchedule returns a TopicSchedule firing every interval, aligned on start.
+// The interval must be positive.
+func IntervalTopicSchedule(start time.Time, interval time.Duration) (TopicSchedule, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("%w: topic schedule interval must be positive, got %v", ErrInvalidConfiguration, interval)
+	}
+
+	return func(after time.Time) time.Time {
+		if after.Before(start) {
+			return start
+		}
+
+		return start.Add((after.Sub(start)/interval + 1) * interval)
+	}, nil
+}
+
+// WeeklyTopicSchedule returns a TopicSchedule firing once a week, on the given weekday and
+// time of day in loc.
+func WeeklyTopicSchedule(weekday time.Weekday, hour, minute int, loc *time.Location) TopicSchedule {
+	return func(after time.Time) time.Time {
+		t := after.In(loc)
+		next := time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, loc)
+		next = next.AddDate(0, 0, (int(weekday)-int(next.Weekday())+7)%7)
+		if !next.After(t) {
+			next = next.AddDate(0, 0, 7)
+		}
+
+		return next
+	}
+}
+
+// TopicChange records a single topic rotation attempt.
+type TopicChange struct {
+	At    time.Time
+	Topic string
+	// Skipped is true when the computed topic was already set and no call was made.
+	Skipped bool
+	Err     error
+}
+
+// TopicRotator periodically updates the topic of a conversation with the value computed by
+// a TopicProvider, following a TopicSchedule. The current topic is read with conversations.info
+// before being replaced with conversations.setTopic, rate limited calls to either are retried
+// once Slack allows it, and every rotation is recorded in the history.
+type TopicRotator struct {
+	api          *Client
+	channelID    string
+	schedule     TopicSchedule
+	provider     TopicProvider
+	historyLimit int
+	now          func() time.Time
+
+	mu      sync.Mutex
+	history []TopicChange
+}
+
+// TopicRotatorOption defines an option for a TopicRotator
+type TopicRotatorOption func(*TopicRotator)
+
+// TopicRotatorOptionHistoryLimit sets how many rotations are kept in the history, 100 by default.
+func TopicRotatorOptionHistoryLimit(limit int) TopicRotatorOption {
+	return func(r *TopicRotator) {
+		r.historyLimit = limit
+	}
+}
+
+// NewTopicRotator returns a TopicRotator for the given conversation. Both schedule and provider
+// are required.
+func (api *Client) NewTopicRotator(channelID string, schedule TopicSchedule, provider TopicProvider, options ...TopicRotatorOption) (*TopicRotator, error) {
+	if schedule == nil {
+		return nil, fmt.Errorf("%w: topic rotator schedule is nil", ErrInvalidConfiguration)
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("%w: topic rotator provider is nil", ErrInvalidConfiguration)
+	}
+
+	r := &TopicRotator{
+		api:          api,
+		channelID:    channelID,
+		schedule:     schedule,
+		provider:     provider,
+		historyLimit: 100,
+		now:          time.Now,
+	}
+
+	for _, opt := range options {
+		opt(r)
+	}
+
+	return r, nil
+}
+
+// Run rotates the topic at every scheduled time until the context is cancelled, which is
+// the only way for Run to return. Failed rotations are recorded in the history and do not
+// stop the rotator.
+func (r *TopicRotator) Run(ctx context.Context) error {
+	for {
+		now := r.now()
+		timer := time.NewTimer(r.schedule(now).Sub(now))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+			change := r.Rotate(ctx)
+			if change.Err != nil {
+				r.api.Debugf("TopicRotator: failed to rotate topic of %s: %v", r.channelID, change.Err)
+			}
+		}
+	}
+}
+
+// Rotate immediately computes the topic and applies it to the conversation, unless it is
+// already the current topic. The current topic is read from the conversation every time, so
+// that a topic changed by hand in the meantime is replaced.
+func (r *TopicRotator) Rotate(ctx context.Context) TopicChange {
+	change := TopicChange{At: r.now()}
+
+	change.Topic, change.Err = r.provider(ctx, change.At)
+	if change.Err == nil {
+		change.Skipped, change.Err = r.apply(ctx, change.Topic)
+	}
+
+	r.record(change)
+
+	return change
+}
+
+func (r *TopicRotator) apply(ctx context.Context, topic string) (skipped bool, err error) {
+	var channel *Channel
+	err = retryRateLimited(ctx, func() (err error) {
+		channel, err = r.api.GetConversationInfoContext(ctx, &GetConversationInfoInput{ChannelID: r.channelID})
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if channel.Topic.Value == topic {
+		return true, nil
+	}
+
+	err = retryRateLimited(ctx, func() error {
+		_, err := r.api.SetTopicOfConversationContext(ctx, r.channelID, topic)
+		return err
+	})
+
+	return false, err
+}
+
+func (r *TopicRotator) record(change TopicChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.history = append(r.history, change)
+	if r.historyLimit > 0 && len(r.history) > r.historyLimit {
+		r.history = r.history[len(r.history)-r.historyLimit:]
+	}
+}
+
+// History returns the recorded rotations, oldest first.
+func (r *TopicRotator) History() []TopicChange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]TopicChange(nil), r.history...)
+}
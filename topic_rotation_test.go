@@ -0,0 +1,170 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervalTopicSchedule(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	schedule, err := IntervalTopicSchedule(start, time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, start, schedule(start.Add(-time.Minute)))
+	assert.Equal(t, start.Add(time.Hour), schedule(start))
+	assert.Equal(t, start.Add(3*time.Hour), schedule(start.Add(150*time.Minute)))
+
+	_, err = IntervalTopicSchedule(start, 0)
+	assert.ErrorIs(t, err, ErrInvalidConfiguration)
+	_, err = IntervalTopicSchedule(start, -time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidConfiguration)
+}
+
+func TestNewTopicRotatorInvalid(t *testing.T) {
+	api := New("testing-token")
+	schedule := WeeklyTopicSchedule(time.Monday, 9, 0, time.UTC)
+
+	_, err := api.NewTopicRotator("CXXXXXXXX", nil, func(context.Context, time.Time) (string, error) { return "", nil })
+	assert.ErrorIs(t, err, ErrInvalidConfiguration)
+	_, err = api.NewTopicRotator("CXXXXXXXX", schedule, nil)
+	assert.ErrorIs(t, err, ErrInvalidConfiguration)
+}
+
+func TestWeeklyTopicSchedule(t *testing.T) {
+	schedule := WeeklyTopicSchedule(time.Monday, 9, 30, time.UTC)
+
+	// 2024-01-03 is a Wednesday.
+	wednesday := time.Date(2024, time.January, 3, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, time.January, 8, 9, 30, 0, 0, time.UTC), schedule(wednesday))
+
+	monday := time.Date(2024, time.January, 8, 9, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, time.January, 15, 9, 30, 0, 0, time.UTC), schedule(monday))
+	assert.Equal(t, monday, schedule(monday.Add(-time.Second)))
+}
+
+// topicServer fakes conversations.info and conversations.setTopic for a single channel.
+type topicServer struct {
+	mu          sync.Mutex
+	topic       string
+	setCalls    int
+	rateLimited int
+}
+
+func (s *topicServer) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rw.Header().Set("Content-Type", "application/json")
+
+	switch r.URL.Path {
+	case "/conversations.info":
+		json.NewEncoder(rw).Encode(map[string]interface{}{
+			"ok":      true,
+			"channel": map[string]interface{}{"id": r.Form.Get("channel"), "topic": map[string]string{"value": s.topic}},
+		})
+	case "/conversations.setTopic":
+		if s.rateLimited > 0 {
+			s.rateLimited--
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		s.setCalls++
+		s.topic = r.Form.Get("topic")
+		json.NewEncoder(rw).Encode(map[string]interface{}{
+			"ok":      true,
+			"channel": map[string]interface{}{"id": r.Form.Get("channel"), "topic": map[string]string{"value": s.topic}},
+		})
+	}
+}
+
+func (s *topicServer) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.setCalls
+}
+
+func TestTopicRotatorRotate(t *testing.T) {
+	// The first call is rate limited and must be retried.
+	fake := &topicServer{rateLimited: 1}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+
+	schedule, err := IntervalTopicSchedule(time.Now(), time.Hour)
+	require.NoError(t, err)
+	week := 0
+	rotator, err := api.NewTopicRotator("CXXXXXXXX", schedule, func(ctx context.Context, at time.Time) (string, error) {
+		if week == 2 {
+			return "", fmt.Errorf("no one on call")
+		}
+		return fmt.Sprintf("On call: person %d", week), nil
+	}, TopicRotatorOptionHistoryLimit(3))
+	require.NoError(t, err)
+
+	change := rotator.Rotate(context.Background())
+	require.NoError(t, change.Err)
+	assert.Equal(t, "On call: person 0", change.Topic)
+	assert.False(t, change.Skipped)
+	assert.Equal(t, 1, fake.calls())
+
+	change = rotator.Rotate(context.Background())
+	require.NoError(t, change.Err)
+	assert.True(t, change.Skipped)
+	assert.Equal(t, 1, fake.calls())
+
+	// A topic changed by hand is replaced at the next rotation.
+	fake.mu.Lock()
+	fake.topic = "Changed by hand"
+	fake.mu.Unlock()
+	change = rotator.Rotate(context.Background())
+	require.NoError(t, change.Err)
+	assert.False(t, change.Skipped)
+	assert.Equal(t, 2, fake.calls())
+	assert.Equal(t, "On call: person 0", fake.topic)
+
+	week = 1
+	rotator.Rotate(context.Background())
+	week = 2
+	change = rotator.Rotate(context.Background())
+	assert.Error(t, change.Err)
+
+	history := rotator.History()
+	require.Len(t, history, 3)
+	assert.False(t, history[0].Skipped)
+	assert.Equal(t, "On call: person 1", history[1].Topic)
+	assert.Error(t, history[2].Err)
+}
+
+func TestTopicRotatorRun(t *testing.T) {
+	fake := &topicServer{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+	api := New("testing-token", OptionAPIURL(srv.URL+"/"))
+
+	schedule, err := IntervalTopicSchedule(time.Now(), 10*time.Millisecond)
+	require.NoError(t, err)
+	var n int32
+	rotator, err := api.NewTopicRotator("CXXXXXXXX", schedule, func(ctx context.Context, at time.Time) (string, error) {
+		return fmt.Sprintf("topic %d", atomic.AddInt32(&n, 1)), nil
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+	defer cancel()
+
+	err = rotator.Run(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.GreaterOrEqual(t, fake.calls(), 2)
+	assert.Len(t, rotator.History(), int(atomic.LoadInt32(&n)))
+}
@@ -332,6 +332,7 @@ func newUserPagination(c *Client, options ...GetUsersOption) (up UserPagination)
 // UserPagination allows for paginating over the users
 type UserPagination struct {
 	Users        []User
+	PageInfo     PageInfo
 	limit        int
 	presence     bool
 	teamId       string
@@ -380,6 +381,8 @@ func (t UserPagination) Next(ctx context.Context) (_ UserPagination, err error)
 	t.c.Debugf("GetUsersContext: got %d users; metadata %v", len(resp.Members), resp.Metadata)
 	t.Users = resp.Members
 	t.previousResp = &resp.Metadata
+	t.PageInfo = newPageInfo(t.limit, 0, len(resp.Members), resp.Metadata.Cursor, false, pageWarnings(resp.Warning, resp.Metadata.Warnings))
+	t.c.debugPageInfo("users.list", t.PageInfo)
 
 	return t, nil
 }
@@ -0,0 +1,421 @@
+// Package userscache provides an in-memory directory of the users of a workspace, to look them
+// up by ID, email or name without calling users.info or users.lookupByEmail every time.
+package userscache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/internal/errorsx"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// ErrUserNotFound is returned when no user of the directory matches a lookup.
+const ErrUserNotFound = errorsx.String("user not found")
+
+// Client is the subset of slack.Client used by the Cache.
+type Client interface {
+	GetUsersContext(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error)
+	GetUserInfoContext(ctx context.Context, user string) (*slack.User, error)
+	GetUsersInfoContext(ctx context.Context, users ...string) (*[]slack.User, error)
+}
+
+// Cache is a directory of users hydrated from users.list.
+//
+// The whole directory is fetched on first use, then again whenever it is older than the TTL.
+// In between, the cache is kept up to date by feeding it the user_change and team_join events
+// with HandleEvent. Users missing from the directory when looked up by ID are fetched with
+// users.info and added to it; lookups by email or name never call Slack beyond the hydration.
+//
+// A Cache is safe for concurrent use.
+type Cache struct {
+	client Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	// refreshMu ensures a single users.list walk runs at a time.
+	refreshMu sync.Mutex
+
+	mu       sync.RWMutex
+	hydrated time.Time
+	byID     map[string]slack.User
+	byEmail  map[string]string
+	byName   map[string]string
+	// byDisplayName only holds display names that are not ambiguous.
+	byDisplayName map[string]string
+	displayNames  map[string]int
+}
+
+// New returns a Cache for the workspace of the client, which is refreshed every ttl. A ttl of
+// zero means the directory is only fetched once.
+func New(client Client, ttl time.Duration) *Cache {
+	return &Cache{
+		client:  client,
+		ttl:     ttl,
+		now:     time.Now,
+		byID:    make(map[string]slack.User),
+		byEmail: make(map[string]string),
+		byName:  make(map[string]string),
+
+		byDisplayName: make(map[string]string),
+		displayNames:  make(map[string]int),
+	}
+}
+
+// Refresh fetches the whole directory with users.list, replacing the cached users.
+func (c *Cache) Refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	return c.refresh(ctx)
+}
+
+func (c *Cache) refresh(ctx context.Context) error {
+	users, err := c.client.GetUsersContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.byID = make(map[string]slack.User, len(users))
+	c.byEmail = make(map[string]string, len(users))
+	c.byName = make(map[string]string, len(users))
+	c.byDisplayName = make(map[string]string, len(users))
+	c.displayNames = make(map[string]int, len(users))
+	for _, user := range users {
+		c.set(user)
+	}
+	c.hydrated = c.now()
+
+	return nil
+}
+
+// ensureFresh hydrates the directory if it was never fetched or is older than the TTL.
+func (c *Cache) ensureFresh(ctx context.Context) error {
+	if !c.stale() {
+		return nil
+	}
+
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	// Another caller may have refreshed the directory while we were waiting.
+	if !c.stale() {
+		return nil
+	}
+
+	return c.refresh(ctx)
+}
+
+func (c *Cache) stale() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.hydrated.IsZero() {
+		return true
+	}
+
+	return c.ttl > 0 && c.now().Sub(c.hydrated) >= c.ttl
+}
+
+// Set adds or replaces a user of the directory.
+func (c *Cache) Set(user slack.User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(user)
+}
+
+func (c *Cache) set(user slack.User) {
+	if previous, ok := c.byID[user.ID]; ok {
+		c.unindex(previous)
+	}
+
+	c.byID[user.ID] = user
+	if user.Profile.Email != "" {
+		c.byEmail[strings.ToLower(user.Profile.Email)] = user.ID
+	}
+	if user.Name != "" {
+		c.byName[strings.ToLower(user.Name)] = user.ID
+	}
+	if key := strings.ToLower(user.Profile.DisplayName); key != "" {
+		c.displayNames[key]++
+		if c.displayNames[key] == 1 {
+			c.byDisplayName[key] = user.ID
+		} else {
+			delete(c.byDisplayName, key)
+		}
+	}
+}
+
+// Delete removes a user from the directory.
+func (c *Cache) Delete(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if user, ok := c.byID[id]; ok {
+		c.unindex(user)
+		delete(c.byID, id)
+	}
+}
+
+func (c *Cache) unindex(user slack.User) {
+	if key := strings.ToLower(user.Profile.Email); c.byEmail[key] == user.ID {
+		delete(c.byEmail, key)
+	}
+	if key := strings.ToLower(user.Name); c.byName[key] == user.ID {
+		delete(c.byName, key)
+	}
+	if key := strings.ToLower(user.Profile.DisplayName); key != "" {
+		c.displayNames[key]--
+		delete(c.byDisplayName, key)
+		switch c.displayNames[key] {
+		case 0:
+			delete(c.displayNames, key)
+		case 1:
+			// The display name is no longer ambiguous.
+			for id, other := range c.byID {
+				if id != user.ID && strings.ToLower(other.Profile.DisplayName) == key {
+					c.byDisplayName[key] = id
+				}
+			}
+		}
+	}
+}
+
+// GetByID returns the user with the given ID, fetching it with users.info if it is not in the
+// directory yet.
+func (c *Cache) GetByID(ctx context.Context, id string) (*slack.User, error) {
+	users, err := c.GetByIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user, ok := users[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+
+	return &user, nil
+}
+
+// GetByIDs returns the users with the given IDs, keyed by ID. The users missing from the
+// directory are fetched with a single users.info call.
+func (c *Cache) GetByIDs(ctx context.Context, ids ...string) (map[string]slack.User, error) {
+	if err := c.ensureFresh(ctx); err != nil {
+		return nil, err
+	}
+
+	users, missing := c.lookup(ids, func(id string) string { return id })
+	if len(missing) == 0 {
+		return users, nil
+	}
+
+	fetched, err := c.fetch(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range fetched {
+		c.Set(user)
+		users[user.ID] = user
+	}
+
+	return users, nil
+}
+
+// fetch gets users with users.info. As Slack rejects the whole call if any of the IDs is
+// unknown, the users are then fetched one by one, leaving out the unknown ones.
+func (c *Cache) fetch(ctx context.Context, ids []string) ([]slack.User, error) {
+	users, err := c.client.GetUsersInfoContext(ctx, ids...)
+	if err == nil {
+		return *users, nil
+	}
+	if !isUserNotFound(err) {
+		return nil, err
+	}
+	if len(ids) == 1 {
+		return nil, nil
+	}
+
+	var found []slack.User
+	for _, id := range ids {
+		user, err := c.client.GetUserInfoContext(ctx, id)
+		if isUserNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, *user)
+	}
+
+	return found, nil
+}
+
+// isUserNotFound reports whether err is the error returned by users.info for unknown users.
+func isUserNotFound(err error) bool {
+	var slackErr slack.SlackErrorResponse
+	return errors.As(err, &slackErr) && slackErr.Err == "user_not_found"
+}
+
+// GetByEmail returns the user with the given email address, compared case insensitively.
+func (c *Cache) GetByEmail(ctx context.Context, email string) (*slack.User, error) {
+	users, err := c.GetByEmails(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		return &user, nil
+	}
+
+	return nil, ErrUserNotFound
+}
+
+// GetByEmails returns the users with the given email addresses, keyed by the addresses as
+// given. Addresses not matching any user are left out of the result.
+func (c *Cache) GetByEmails(ctx context.Context, emails ...string) (map[string]slack.User, error) {
+	if err := c.ensureFresh(ctx); err != nil {
+		return nil, err
+	}
+
+	users, _ := c.lookup(emails, func(email string) string { return c.byEmail[strings.ToLower(email)] })
+
+	return users, nil
+}
+
+// GetByName returns the user with the given username or, failing that, display name, compared
+// case insensitively. Display names shared by several users are not resolved.
+func (c *Cache) GetByName(ctx context.Context, name string) (*slack.User, error) {
+	if err := c.ensureFresh(ctx); err != nil {
+		return nil, err
+	}
+
+	users, _ := c.lookup([]string{name}, func(name string) string {
+		key := strings.ToLower(name)
+		if id, ok := c.byName[key]; ok {
+			return id
+		}
+		return c.byDisplayName[key]
+	})
+	if user, ok := users[name]; ok {
+		return &user, nil
+	}
+
+	return nil, ErrUserNotFound
+}
+
+// ResolveUserID returns the ID of the user with the given username or display name, or an empty
+// ID if there is none. It is a slack.UserIDResolver, e.g. for slack.MsgOptionResolveNames.
+func (c *Cache) ResolveUserID(ctx context.Context, name string) (string, error) {
+	user, err := c.GetByName(ctx, name)
+	if errors.Is(err, ErrUserNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return user.ID, nil
+}
+
+// lookup resolves keys to users, with resolve mapping a key to a user ID while the cache is
+// locked, and returns the keys that could not be resolved.
+func (c *Cache) lookup(keys []string, resolve func(key string) string) (map[string]slack.User, []string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	users := make(map[string]slack.User, len(keys))
+	var missing []string
+	for _, key := range keys {
+		if user, ok := c.byID[resolve(key)]; ok {
+			users[key] = user
+		} else {
+			missing = append(missing, key)
+		}
+	}
+
+	return users, missing
+}
+
+// eventUser converts the user of a slackevents.UserChangeEvent, keeping the cached email address
+// the event lacks.
+func (c *Cache) eventUser(evtUser slackevents.User) (slack.User, error) {
+	var user slack.User
+	raw, err := json.Marshal(evtUser)
+	if err != nil {
+		return user, err
+	}
+	if err := json.Unmarshal(raw, &user); err != nil {
+		return user, err
+	}
+
+	c.mu.RLock()
+	if cached, ok := c.byID[user.ID]; ok && user.Profile.Email == "" {
+		user.Profile.Email = cached.Profile.Email
+	}
+	c.mu.RUnlock()
+
+	return user, nil
+}
+
+// HandleEvent updates the directory from an event, and ignores the events unrelated to users.
+// It accepts both the Events API events of slackevents and the RTM events of slack, by value or
+// by pointer. As the user of a slackevents.UserChangeEvent lacks the email address, the cached one
+// is kept, so a changed address is only seen at the next refresh. Users without a cached address
+// are fetched with users.info instead: they are left out of the directory if Slack does not know
+// them, and the event is used as is if the call fails otherwise.
+func (c *Cache) HandleEvent(ctx context.Context, evt interface{}) error {
+	switch e := evt.(type) {
+	case slackevents.EventsAPIEvent:
+		return c.HandleEvent(ctx, e.InnerEvent.Data)
+	case *slackevents.EventsAPIEvent:
+		return c.HandleEvent(ctx, e.InnerEvent.Data)
+	case slackevents.TeamJoinEvent:
+		return c.HandleEvent(ctx, &e)
+	case *slackevents.TeamJoinEvent:
+		if e.User != nil {
+			c.Set(*e.User)
+		}
+	case slackevents.UserChangeEvent:
+		return c.HandleEvent(ctx, &e)
+	case *slackevents.UserChangeEvent:
+		evtUser, convErr := c.eventUser(e.User)
+		if convErr == nil && evtUser.Profile.Email != "" {
+			c.Set(evtUser)
+			return nil
+		}
+
+		user, err := c.client.GetUserInfoContext(ctx, e.User.ID)
+		if isUserNotFound(err) {
+			c.Delete(e.User.ID)
+			return nil
+		}
+		if err != nil {
+			// Keep what the event tells about the user until it can be fetched again.
+			if convErr == nil {
+				c.Set(evtUser)
+			}
+			return err
+		}
+		c.Set(*user)
+	case slack.TeamJoinEvent:
+		c.Set(e.User)
+	case *slack.TeamJoinEvent:
+		c.Set(e.User)
+	case slack.UserChangeEvent:
+		c.Set(e.User)
+	case *slack.UserChangeEvent:
+		c.Set(e.User)
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package userscache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+type fakeClient struct {
+	users     []slack.User
+	listCalls int
+	infoCalls [][]string
+	// infoErr is returned by users.info calls when set.
+	infoErr error
+}
+
+func (f *fakeClient) GetUsersContext(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error) {
+	f.listCalls++
+	return append([]slack.User(nil), f.users...), nil
+}
+
+func (f *fakeClient) GetUserInfoContext(ctx context.Context, user string) (*slack.User, error) {
+	users, err := f.GetUsersInfoContext(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &(*users)[0], nil
+}
+
+// GetUsersInfoContext fails with user_not_found if any of the IDs is unknown, as Slack does.
+func (f *fakeClient) GetUsersInfoContext(ctx context.Context, ids ...string) (*[]slack.User, error) {
+	f.infoCalls = append(f.infoCalls, ids)
+	if f.infoErr != nil {
+		return nil, f.infoErr
+	}
+
+	var users []slack.User
+	for _, id := range ids {
+		found := false
+		for _, user := range f.users {
+			if user.ID == id {
+				users = append(users, user)
+				found = true
+			}
+		}
+		if !found {
+			return nil, slack.SlackErrorResponse{Err: "user_not_found"}
+		}
+	}
+	return &users, nil
+}
+
+func newUser(id, name, email string) slack.User {
+	return slack.User{ID: id, Name: name, Profile: slack.UserProfile{Email: email}}
+}
+
+func TestCacheLookups(t *testing.T) {
+	client := &fakeClient{users: []slack.User{
+		newUser("U1", "alice", "alice@example.com"),
+		newUser("U2", "bob", "Bob@example.com"),
+	}}
+	cache := New(client, time.Hour)
+	ctx := context.Background()
+
+	user, err := cache.GetByEmail(ctx, "bob@EXAMPLE.com")
+	require.NoError(t, err)
+	assert.Equal(t, "U2", user.ID)
+
+	user, err = cache.GetByID(ctx, "U1")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Name)
+
+	user, err = cache.GetByName(ctx, "Alice")
+	require.NoError(t, err)
+	assert.Equal(t, "U1", user.ID)
+
+	_, err = cache.GetByEmail(ctx, "carol@example.com")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	users, err := cache.GetByEmails(ctx, "alice@example.com", "carol@example.com")
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "U1", users["alice@example.com"].ID)
+
+	assert.Equal(t, 1, client.listCalls)
+	assert.Empty(t, client.infoCalls)
+}
+
+func TestCacheFetchesMissingIDs(t *testing.T) {
+	client := &fakeClient{users: []slack.User{newUser("U1", "alice", "alice@example.com")}}
+	cache := New(client, time.Hour)
+	ctx := context.Background()
+
+	require.NoError(t, cache.Refresh(ctx))
+	client.users = append(client.users, newUser("U2", "bob", "bob@example.com"), newUser("U3", "carol", "carol@example.com"))
+
+	users, err := cache.GetByIDs(ctx, "U1", "U2", "U3", "U4")
+	require.NoError(t, err)
+	assert.Len(t, users, 3)
+	assert.Equal(t, [][]string{{"U2", "U3", "U4"}, {"U2"}, {"U3"}, {"U4"}}, client.infoCalls)
+
+	// Fetched users are now part of the directory.
+	user, err := cache.GetByEmail(ctx, "carol@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "U3", user.ID)
+
+	_, err = cache.GetByID(ctx, "U4")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	client.infoErr = errors.New("timeout")
+	_, err = cache.GetByID(ctx, "U5")
+	assert.EqualError(t, err, "timeout")
+}
+
+func TestCacheTTL(t *testing.T) {
+	client := &fakeClient{users: []slack.User{newUser("U1", "alice", "alice@example.com")}}
+	cache := New(client, time.Minute)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := cache.GetByID(ctx, "U1")
+	require.NoError(t, err)
+	now = now.Add(30 * time.Second)
+	_, err = cache.GetByID(ctx, "U1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.listCalls)
+
+	now = now.Add(time.Minute)
+	_, err = cache.GetByID(ctx, "U1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.listCalls)
+}
+
+func TestCacheHandleEvent(t *testing.T) {
+	client := &fakeClient{users: []slack.User{newUser("U1", "alice", "alice@example.com")}}
+	cache := New(client, 0)
+	ctx := context.Background()
+	require.NoError(t, cache.Refresh(ctx))
+
+	joined := newUser("U2", "bob", "bob@example.com")
+	require.NoError(t, cache.HandleEvent(ctx, slackevents.EventsAPIEvent{
+		InnerEvent: slackevents.EventsAPIInnerEvent{Data: &slackevents.TeamJoinEvent{User: &joined}},
+	}))
+	user, err := cache.GetByEmail(ctx, "bob@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "U2", user.ID)
+
+	// An Events API user_change keeps the cached email, which the event lacks, without calling Slack.
+	require.NoError(t, cache.HandleEvent(ctx, &slackevents.UserChangeEvent{User: slackevents.User{ID: "U1", Name: "alicia"}}))
+	assert.Empty(t, client.infoCalls)
+	user, err = cache.GetByName(ctx, "alicia")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", user.Profile.Email)
+
+	// Users without a cached email are fetched with users.info.
+	client.users = append(client.users, newUser("U3", "carol", "carol@example.com"))
+	require.NoError(t, cache.HandleEvent(ctx, &slackevents.UserChangeEvent{User: slackevents.User{ID: "U3", Name: "carol"}}))
+	assert.Equal(t, [][]string{{"U3"}}, client.infoCalls)
+	user, err = cache.GetByEmail(ctx, "carol@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "U3", user.ID)
+
+	// Failing to fetch the user keeps the event's data, and unknown users are left out.
+	client.infoErr = errors.New("timeout")
+	err = cache.HandleEvent(ctx, &slackevents.UserChangeEvent{User: slackevents.User{ID: "U4", Name: "dave"}})
+	assert.EqualError(t, err, "timeout")
+	user, err = cache.GetByName(ctx, "dave")
+	require.NoError(t, err)
+	assert.Equal(t, "U4", user.ID)
+
+	client.infoErr = nil
+	require.NoError(t, cache.HandleEvent(ctx, &slackevents.UserChangeEvent{User: slackevents.User{ID: "U4", Name: "dave"}}))
+	_, err = cache.GetByName(ctx, "dave")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	// RTM events carry the complete user.
+	require.NoError(t, cache.HandleEvent(ctx, &slack.UserChangeEvent{User: newUser("U2", "robert", "bob@example.com")}))
+	user, err = cache.GetByName(ctx, "robert")
+	require.NoError(t, err)
+	assert.Equal(t, "U2", user.ID)
+	_, err = cache.GetByName(ctx, "bob")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	assert.Equal(t, 1, client.listCalls)
+}
+
+func TestCacheResolveUserID(t *testing.T) {
+	carol := newUser("U3", "carol", "")
+	carol.Profile.DisplayName = "Carol D"
+	dave := newUser("U4", "dave", "")
+	dave.Profile.DisplayName = "twin"
+	erin := newUser("U5", "erin", "")
+	erin.Profile.DisplayName = "twin"
+	client := &fakeClient{users: []slack.User{newUser("U1", "alice", ""), carol, dave, erin}}
+	cache := New(client, time.Hour)
+	ctx := context.Background()
+
+	for name, expected := range map[string]string{
+		"alice":   "U1",
+		"carol d": "U3",
+		"twin":    "",
+		"nobody":  "",
+	} {
+		id, err := cache.ResolveUserID(ctx, name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, id, name)
+	}
+
+	cache.Delete("U5")
+	id, err := cache.ResolveUserID(ctx, "twin")
+	require.NoError(t, err)
+	assert.Equal(t, "U4", id)
+
+	dave.Profile.DisplayName = "dave"
+	cache.Set(dave)
+	id, err = cache.ResolveUserID(ctx, "twin")
+	require.NoError(t, err)
+	assert.Equal(t, "", id)
+}
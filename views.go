@@ -3,6 +3,8 @@ package slack
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 )
 
 const (
@@ -16,6 +18,180 @@ type ViewState struct {
 	Values map[string]map[string]BlockAction `json:"values"`
 }
 
+// Get returns the state value of the element identified by blockID and actionID.
+// The returned error wraps ErrStateValueNotFound if there is no such element.
+func (s *ViewState) Get(blockID, actionID string) (BlockAction, error) {
+	if s != nil {
+		if action, ok := s.Values[blockID][actionID]; ok {
+			return action, nil
+		}
+	}
+
+	return BlockAction{}, fmt.Errorf("%w: block %q, action %q", ErrStateValueNotFound, blockID, actionID)
+}
+
+// getTyped returns the state value of the element identified by blockID and
+// actionID, making sure it is one of the given element types.
+func (s *ViewState) getTyped(blockID, actionID string, types ...string) (BlockAction, error) {
+	action, err := s.Get(blockID, actionID)
+	if err != nil {
+		return action, err
+	}
+
+	for _, t := range types {
+		if string(action.Type) == t {
+			return action, nil
+		}
+	}
+
+	return action, fmt.Errorf("%w: block %q, action %q is a %s", ErrStateValueType, blockID, actionID, action.Type)
+}
+
+// GetString returns the value of a plain text, email, URL or number input.
+func (s *ViewState) GetString(blockID, actionID string) (string, error) {
+	action, err := s.getTyped(blockID, actionID,
+		string(METPlainTextInput), string(METEmailTextInput), string(METURLTextInput), string(METNumber))
+	if err != nil {
+		return "", err
+	}
+
+	return action.Value, nil
+}
+
+// GetSelectedOption returns the option selected in a static or external select
+// menu, or in a radio buttons group.
+func (s *ViewState) GetSelectedOption(blockID, actionID string) (OptionBlockObject, error) {
+	action, err := s.getTyped(blockID, actionID, OptTypeStatic, OptTypeExternal, string(METRadioButtons))
+	if err != nil {
+		return OptionBlockObject{}, err
+	}
+
+	return action.SelectedOption, nil
+}
+
+// GetSelectedOptions returns the options selected in a multi static or multi
+// external select menu, or in a checkboxes group.
+func (s *ViewState) GetSelectedOptions(blockID, actionID string) ([]OptionBlockObject, error) {
+	action, err := s.getTyped(blockID, actionID, MultiOptTypeStatic, MultiOptTypeExternal, string(METCheckboxGroups))
+	if err != nil {
+		return nil, err
+	}
+
+	return action.SelectedOptions, nil
+}
+
+// GetSelectedDate returns the date selected in a date picker, at midnight UTC.
+// The zero time is returned if no date is selected.
+func (s *ViewState) GetSelectedDate(blockID, actionID string) (time.Time, error) {
+	action, err := s.getTyped(blockID, actionID, string(METDatepicker))
+	if err != nil || action.SelectedDate == "" {
+		return time.Time{}, err
+	}
+
+	return time.Parse("2006-01-02", action.SelectedDate)
+}
+
+// GetSelectedTime returns the time selected in a time picker as an offset from
+// midnight. Slack reports the time as HH:mm, so the offset has minute precision.
+// Zero is returned if no time is selected.
+func (s *ViewState) GetSelectedTime(blockID, actionID string) (time.Duration, error) {
+	action, err := s.getTyped(blockID, actionID, string(METTimepicker))
+	if err != nil || action.SelectedTime == "" {
+		return 0, err
+	}
+
+	t, err := time.Parse("15:04", action.SelectedTime)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// GetSelectedDateTime returns the date and time selected in a datetime picker.
+// The zero time is returned if nothing is selected.
+func (s *ViewState) GetSelectedDateTime(blockID, actionID string) (time.Time, error) {
+	action, err := s.getTyped(blockID, actionID, string(METDatetimepicker))
+	if err != nil || action.SelectedDateTime == 0 {
+		return time.Time{}, err
+	}
+
+	return time.Unix(action.SelectedDateTime, 0), nil
+}
+
+// GetSelectedUser returns the ID of the user selected in a users select menu.
+func (s *ViewState) GetSelectedUser(blockID, actionID string) (string, error) {
+	action, err := s.getTyped(blockID, actionID, OptTypeUser)
+	if err != nil {
+		return "", err
+	}
+
+	return action.SelectedUser, nil
+}
+
+// GetSelectedUsers returns the IDs of the users selected in a multi users select menu.
+func (s *ViewState) GetSelectedUsers(blockID, actionID string) ([]string, error) {
+	action, err := s.getTyped(blockID, actionID, MultiOptTypeUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return action.SelectedUsers, nil
+}
+
+// GetSelectedConversation returns the ID of the conversation selected in a
+// conversations select menu.
+func (s *ViewState) GetSelectedConversation(blockID, actionID string) (string, error) {
+	action, err := s.getTyped(blockID, actionID, OptTypeConversations)
+	if err != nil {
+		return "", err
+	}
+
+	return action.SelectedConversation, nil
+}
+
+// GetSelectedConversations returns the IDs of the conversations selected in a
+// multi conversations select menu.
+func (s *ViewState) GetSelectedConversations(blockID, actionID string) ([]string, error) {
+	action, err := s.getTyped(blockID, actionID, MultiOptTypeConversations)
+	if err != nil {
+		return nil, err
+	}
+
+	return action.SelectedConversations, nil
+}
+
+// GetSelectedChannel returns the ID of the channel selected in a channels select menu.
+func (s *ViewState) GetSelectedChannel(blockID, actionID string) (string, error) {
+	action, err := s.getTyped(blockID, actionID, OptTypeChannels)
+	if err != nil {
+		return "", err
+	}
+
+	return action.SelectedChannel, nil
+}
+
+// GetSelectedChannels returns the IDs of the channels selected in a multi
+// channels select menu.
+func (s *ViewState) GetSelectedChannels(blockID, actionID string) ([]string, error) {
+	action, err := s.getTyped(blockID, actionID, MultiOptTypeChannels)
+	if err != nil {
+		return nil, err
+	}
+
+	return action.SelectedChannels, nil
+}
+
+// GetRichTextValue returns the content of a rich text input.
+func (s *ViewState) GetRichTextValue(blockID, actionID string) (RichTextBlock, error) {
+	action, err := s.getTyped(blockID, actionID, string(METRichTextInput))
+	if err != nil {
+		return RichTextBlock{}, err
+	}
+
+	return action.RichTextValue, nil
+}
+
 type View struct {
 	SlackResponse
 	ID                 string           `json:"id"`
@@ -2,9 +2,11 @@ package slack
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -846,3 +848,70 @@ func TestSlack_ErrorsViewSubmissionResponse(t *testing.T) {
 
 	assertViewSubmissionResponse(t, resp, rawResp)
 }
+
+func TestViewState_Getters(t *testing.T) {
+	payload := `{
+		"values": {
+			"name": {"name_input": {"type": "plain_text_input", "value": "Jane"}},
+			"size": {"size_select": {"type": "static_select", "selected_option": {"text": {"type": "plain_text", "text": "Large"}, "value": "L"}}},
+			"toppings": {"toppings_boxes": {"type": "checkboxes", "selected_options": [{"value": "cheese"}, {"value": "olives"}]}},
+			"when": {"date": {"type": "datepicker", "selected_date": "2024-03-01"}},
+			"at": {"time": {"type": "timepicker", "selected_time": "13:45"}},
+			"deadline": {"dt": {"type": "datetimepicker", "selected_date_time": 1709300000}},
+			"owners": {"users": {"type": "multi_users_select", "selected_users": ["U1", "U2"]}},
+			"notes": {"rich": {"type": "rich_text_input", "rich_text_value": {"type": "rich_text", "elements": []}}},
+			"empty": {"date": {"type": "datepicker"}}
+		}
+	}`
+
+	var state ViewState
+	if err := json.Unmarshal([]byte(payload), &state); err != nil {
+		t.Fatal(err)
+	}
+
+	name, err := state.GetString("name", "name_input")
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane", name)
+
+	option, err := state.GetSelectedOption("size", "size_select")
+	assert.NoError(t, err)
+	assert.Equal(t, "L", option.Value)
+
+	options, err := state.GetSelectedOptions("toppings", "toppings_boxes")
+	assert.NoError(t, err)
+	assert.Len(t, options, 2)
+
+	date, err := state.GetSelectedDate("when", "date")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), date)
+
+	offset, err := state.GetSelectedTime("at", "time")
+	assert.NoError(t, err)
+	assert.Equal(t, 13*time.Hour+45*time.Minute, offset)
+
+	dt, err := state.GetSelectedDateTime("deadline", "dt")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1709300000), dt.Unix())
+
+	users, err := state.GetSelectedUsers("owners", "users")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"U1", "U2"}, users)
+
+	rich, err := state.GetRichTextValue("notes", "rich")
+	assert.NoError(t, err)
+	assert.Equal(t, MBTRichText, rich.Type)
+
+	empty, err := state.GetSelectedDate("empty", "date")
+	assert.NoError(t, err)
+	assert.True(t, empty.IsZero())
+
+	_, err = state.GetString("missing", "name_input")
+	assert.True(t, errors.Is(err, ErrStateValueNotFound))
+
+	_, err = state.GetString("size", "size_select")
+	assert.True(t, errors.Is(err, ErrStateValueType))
+
+	var nilState *ViewState
+	_, err = nilState.GetSelectedUser("owners", "users")
+	assert.True(t, errors.Is(err, ErrStateValueNotFound))
+}
@@ -0,0 +1,282 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack/internal/backoff"
+	"github.com/slack-go/slack/internal/errorsx"
+)
+
+// Errors returned by incoming webhooks, which can be matched with errors.Is.
+// See https://api.slack.com/messaging/webhooks#handling_errors
+const (
+	ErrWebhookInvalidPayload                = errorsx.String("invalid_payload")
+	ErrWebhookUserNotFound                  = errorsx.String("user_not_found")
+	ErrWebhookChannelNotFound               = errorsx.String("channel_not_found")
+	ErrWebhookChannelIsArchived             = errorsx.String("channel_is_archived")
+	ErrWebhookActionProhibited              = errorsx.String("action_prohibited")
+	ErrWebhookPostingToGeneralChannelDenied = errorsx.String("posting_to_general_channel_denied")
+	ErrWebhookTooManyAttachments            = errorsx.String("too_many_attachments")
+	ErrWebhookNoText                        = errorsx.String("no_text")
+	ErrWebhookNoService                     = errorsx.String("no_service")
+	ErrWebhookNoServiceID                   = errorsx.String("no_service_id")
+	ErrWebhookNoTeam                        = errorsx.String("no_team")
+	ErrWebhookTeamDisabled                  = errorsx.String("team_disabled")
+	ErrWebhookInvalidToken                  = errorsx.String("invalid_token")
+)
+
+// WebhookError is an error returned by an incoming webhook, which reports it as the plain text
+// body of a non 200 response, e.g. channel_is_archived. It unwraps to a StatusCodeError, which
+// implements APIError.
+type WebhookError struct {
+	StatusCode int
+	Status     string
+	// Err is the error code returned by Slack, e.g. invalid_payload.
+	Err string
+}
+
+func (e WebhookError) Error() string {
+	if e.Err == "" {
+		return StatusCodeError{Code: e.StatusCode, Status: e.Status}.Error()
+	}
+
+	return e.Err
+}
+
+// Is reports whether target is the error code of e, e.g. ErrWebhookChannelIsArchived.
+func (e WebhookError) Is(target error) bool {
+	return target != nil && e.Err != "" && target.Error() == e.Err
+}
+
+// Unwrap returns the StatusCodeError of the response.
+func (e WebhookError) Unwrap() error {
+	return StatusCodeError{Code: e.StatusCode, Status: e.Status}
+}
+
+// HTTPStatusCode returns the status code of the HTTP response.
+func (e WebhookError) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+// Retryable reports whether the request may succeed if retried, i.e. Slack was rate limiting
+// or failing.
+func (e WebhookError) Retryable() bool {
+	return StatusCodeError{Code: e.StatusCode, Status: e.Status}.Retryable()
+}
+
+// WebhookClient posts messages to an incoming webhook, retrying when Slack is rate limiting,
+// and optionally when it is failing.
+type WebhookClient struct {
+	url               string
+	httpclient        httpClient
+	middleware        []RequestMiddleware
+	maxRetries        int
+	backoff           backoff.Backoff
+	retryServerErrors bool
+}
+
+// WebhookOption configures a WebhookClient.
+type WebhookOption func(*WebhookClient)
+
+// WebhookOptionHTTPClient sets the HTTP client of the webhook client, http.DefaultClient by
+// default.
+func WebhookOptionHTTPClient(client httpClient) WebhookOption {
+	return func(c *WebhookClient) {
+		c.httpclient = client
+	}
+}
+
+// WebhookOptionRetry sets how many times a post is retried after a 429 response, or a 5xx one
+// with WebhookOptionRetryServerErrors, 3 by default. Rate limited posts wait for the delay given
+// by Slack, the others for an exponential backoff between initial and max.
+func WebhookOptionRetry(maxRetries int, initial, max time.Duration) WebhookOption {
+	return func(c *WebhookClient) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff.Backoff{Initial: initial, Max: max}
+	}
+}
+
+// WebhookOptionRetryServerErrors also retries the posts Slack answers with a 5xx response.
+// Slack may have posted the message before failing, so the message may be posted twice.
+func WebhookOptionRetryServerErrors() WebhookOption {
+	return func(c *WebhookClient) {
+		c.retryServerErrors = true
+	}
+}
+
+// WebhookOptionRequestMiddleware adds middlewares wrapping the requests of the webhook client,
+// see OptionRequestMiddleware.
+func WebhookOptionRequestMiddleware(middleware ...RequestMiddleware) WebhookOption {
+	return func(c *WebhookClient) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// NewWebhookClient returns a client posting to the incoming webhook at url.
+func NewWebhookClient(url string, options ...WebhookOption) *WebhookClient {
+	c := &WebhookClient{
+		url:        url,
+		httpclient: http.DefaultClient,
+		maxRetries: 3,
+		backoff:    backoff.Backoff{Initial: time.Second, Max: 30 * time.Second},
+	}
+
+	for _, opt := range options {
+		opt(c)
+	}
+
+	return c
+}
+
+// Send posts a message built from options, see NewWebhookMessage.
+// For more details, see SendContext documentation.
+func (c *WebhookClient) Send(options ...MsgOption) error {
+	return c.SendContext(context.Background(), options...)
+}
+
+// SendContext posts a message built from options, see NewWebhookMessage, with a custom context.
+func (c *WebhookClient) SendContext(ctx context.Context, options ...MsgOption) error {
+	msg, err := newWebhookMessage(ctx, options...)
+	if err != nil {
+		return err
+	}
+
+	return c.PostContext(ctx, msg)
+}
+
+// Post posts a message to the webhook.
+// For more details, see PostContext documentation.
+func (c *WebhookClient) Post(msg *WebhookMessage) error {
+	return c.PostContext(context.Background(), msg)
+}
+
+// PostContext posts a message to the webhook with a custom context. Errors reported by Slack
+// are returned as a WebhookError, or a *RateLimitedError once the retries are exhausted.
+// Failing posts are not retried unless enabled with WebhookOptionRetryServerErrors.
+func (c *WebhookClient) PostContext(ctx context.Context, msg *WebhookMessage) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+
+	b := c.backoff
+	for attempt := 0; ; attempt++ {
+		err = c.post(ctx, raw)
+		if err == nil || attempt >= c.maxRetries {
+			return err
+		}
+
+		wait, ok := rateLimited(err)
+		if !ok {
+			if !c.retryable(err) {
+				return err
+			}
+			wait = b.Duration()
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// retryable reports whether a post failing with err, other than a *RateLimitedError, is
+// retried: 429 responses without a delay always are, 5xx ones only if enabled.
+func (c *WebhookClient) retryable(err error) bool {
+	var webhookErr WebhookError
+	if !errors.As(err, &webhookErr) {
+		return false
+	}
+
+	return webhookErr.StatusCode == http.StatusTooManyRequests || (c.retryServerErrors && webhookErr.Retryable())
+}
+
+func (c *WebhookClient) post(ctx context.Context, raw []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := chainMiddleware(c.middleware, c.httpclient.Do)(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && resp.Header.Get("Retry-After") != "" {
+		if retry, err := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64); err == nil {
+			return &RateLimitedError{time.Duration(retry) * time.Second}
+		}
+	}
+
+	// Slack answers with an error code, except for some 5xx responses which hold an HTML page.
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	code := strings.TrimSpace(string(body))
+	if strings.ContainsAny(code, " <\n") {
+		code = ""
+	}
+
+	return WebhookError{StatusCode: resp.StatusCode, Status: resp.Status, Err: code}
+}
+
+// NewWebhookMessage builds a webhook message with MsgOption builders, e.g. MsgOptionText,
+// MsgOptionBlocks or MsgOptionIconEmoji. Options without an equivalent in incoming webhooks,
+// like MsgOptionMetadata, are ignored.
+func NewWebhookMessage(options ...MsgOption) (*WebhookMessage, error) {
+	return newWebhookMessage(context.Background(), options...)
+}
+
+func newWebhookMessage(ctx context.Context, options ...MsgOption) (*WebhookMessage, error) {
+	config, err := applyMsgOptions("", "", "", options...)
+	if err != nil {
+		return nil, err
+	}
+
+	text := config.values.Get("text")
+	if config.nameResolvers != nil {
+		if text, err = config.nameResolvers.resolve(ctx, text); err != nil {
+			return nil, err
+		}
+	}
+
+	msg := &WebhookMessage{
+		Username:        config.values.Get("username"),
+		IconEmoji:       config.values.Get("icon_emoji"),
+		IconURL:         config.values.Get("icon_url"),
+		Channel:         config.values.Get("channel"),
+		ThreadTimestamp: config.values.Get("thread_ts"),
+		Text:            text,
+		Attachments:     config.attachments,
+		Parse:           config.values.Get("parse"),
+		ResponseType:    config.responseType,
+		ReplaceOriginal: config.replaceOriginal,
+		DeleteOriginal:  config.deleteOriginal,
+		ReplyBroadcast:  config.values.Get("reply_broadcast") == "true",
+		UnfurlLinks:     config.values.Get("unfurl_links") == "true",
+		UnfurlMedia:     config.values.Get("unfurl_media") == "true",
+		unfurlLinksSet:  config.values.Has("unfurl_links"),
+		unfurlMediaSet:  config.values.Has("unfurl_media"),
+	}
+	if len(config.blocks.BlockSet) > 0 {
+		msg.Blocks = &Blocks{BlockSet: config.blocks.BlockSet}
+	}
+
+	return msg, nil
+}
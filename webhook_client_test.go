@@ -0,0 +1,143 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhookMessage(t *testing.T) {
+	section := NewSectionBlock(NewTextBlockObject(MarkdownType, "*hello*", false, false), nil, nil)
+	msg, err := NewWebhookMessage(
+		MsgOptionText("hello @alice", false),
+		MsgOptionBlocks(section),
+		MsgOptionUsername("bot"),
+		MsgOptionIconEmoji(":robot_face:"),
+		MsgOptionTS("1.0"),
+		MsgOptionBroadcast(),
+		MsgOptionResolveNames(mapResolver(map[string]string{"alice": "U1"}), nil),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, &WebhookMessage{
+		Username:        "bot",
+		IconEmoji:       ":robot_face:",
+		ThreadTimestamp: "1.0",
+		Text:            "hello <@U1>",
+		Blocks:          &Blocks{BlockSet: []Block{section}},
+		ReplyBroadcast:  true,
+	}, msg)
+}
+
+func TestNewWebhookMessageUnfurl(t *testing.T) {
+	msg, err := NewWebhookMessage(MsgOptionText("hello", false), MsgOptionDisableLinkUnfurl(), MsgOptionDisableMediaUnfurl())
+	require.NoError(t, err)
+	raw, err := json.Marshal(msg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"hello","replace_original":false,"delete_original":false,"unfurl_links":false,"unfurl_media":false}`, string(raw))
+
+	msg, err = NewWebhookMessage(MsgOptionText("hello", false), MsgOptionEnableLinkUnfurl())
+	require.NoError(t, err)
+	raw, err = json.Marshal(msg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"hello","replace_original":false,"delete_original":false,"unfurl_links":true}`, string(raw))
+
+	raw, err = json.Marshal(WebhookMessage{Text: "hello"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"hello","replace_original":false,"delete_original":false}`, string(raw))
+}
+
+func TestWebhookClientPost(t *testing.T) {
+	var (
+		attempts int
+		received WebhookMessage
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			rw.Write([]byte("rate_limited"))
+		case 2:
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			rw.Write([]byte("<html><body>Service Unavailable</body></html>"))
+		default:
+			json.NewDecoder(r.Body).Decode(&received)
+			rw.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	client := NewWebhookClient(srv.URL, WebhookOptionHTTPClient(srv.Client()), WebhookOptionRetry(2, time.Millisecond, time.Millisecond))
+	err := client.Send(MsgOptionText("hello", false))
+	assert.EqualError(t, err, "slack server error: 503 Service Unavailable")
+	assert.Equal(t, 2, attempts, "server errors must only be retried when enabled")
+
+	attempts = 0
+	client = NewWebhookClient(srv.URL, WebhookOptionHTTPClient(srv.Client()), WebhookOptionRetry(2, time.Millisecond, time.Millisecond), WebhookOptionRetryServerErrors())
+	require.NoError(t, client.Send(MsgOptionText("hello", false)))
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, "hello", received.Text)
+}
+
+func TestWebhookClientErrors(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch r.URL.Path {
+		case "/archived":
+			rw.WriteHeader(http.StatusGone)
+			rw.Write([]byte("channel_is_archived\n"))
+		case "/failing":
+			rw.WriteHeader(http.StatusInternalServerError)
+			rw.Write([]byte("<html>oops</html>"))
+		}
+	}))
+	defer srv.Close()
+
+	err := NewWebhookClient(srv.URL + "/archived").Post(&WebhookMessage{Text: "hello"})
+	assert.ErrorIs(t, err, ErrWebhookChannelIsArchived)
+	assert.NotErrorIs(t, err, ErrWebhookInvalidPayload)
+	var webhookErr WebhookError
+	require.ErrorAs(t, err, &webhookErr)
+	assert.Equal(t, WebhookError{StatusCode: http.StatusGone, Status: "410 Gone", Err: "channel_is_archived"}, webhookErr)
+	var statusErr StatusCodeError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusGone, statusErr.Code)
+	var apiErr APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusGone, apiErr.HTTPStatusCode())
+	assert.Equal(t, 1, attempts, "client errors must not be retried")
+
+	attempts = 0
+	err = NewWebhookClient(srv.URL+"/failing", WebhookOptionRetry(1, time.Millisecond, time.Millisecond), WebhookOptionRetryServerErrors()).Post(&WebhookMessage{Text: "hello"})
+	require.ErrorAs(t, err, &webhookErr)
+	assert.Equal(t, "", webhookErr.Err)
+	assert.EqualError(t, err, "slack server error: 500 Internal Server Error")
+	assert.Equal(t, 2, attempts)
+}
+
+func TestWebhookClientContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Retry-After", "60")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	var calls []string
+	client := NewWebhookClient(srv.URL, WebhookOptionRequestMiddleware(headerMiddleware("X-Proxy-Tag", "webhook", &calls)))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := client.PostContext(ctx, &WebhookMessage{Text: "hello"})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, []string{"webhook"}, calls)
+}
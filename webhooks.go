@@ -25,6 +25,31 @@ type WebhookMessage struct {
 	ReplyBroadcast  bool         `json:"reply_broadcast,omitempty"`
 	UnfurlLinks     bool         `json:"unfurl_links,omitempty"`
 	UnfurlMedia     bool         `json:"unfurl_media,omitempty"`
+
+	// unfurlLinksSet and unfurlMediaSet are set by NewWebhookMessage when the unfurl options
+	// are given, so that disabling unfurling is sent rather than left out as a false value.
+	unfurlLinksSet bool
+	unfurlMediaSet bool
+}
+
+// MarshalJSON implements json.Marshaler, sending the unfurl settings of messages built with
+// NewWebhookMessage even when they are false.
+func (msg WebhookMessage) MarshalJSON() ([]byte, error) {
+	type alias WebhookMessage
+	out := struct {
+		alias
+		UnfurlLinks *bool `json:"unfurl_links,omitempty"`
+		UnfurlMedia *bool `json:"unfurl_media,omitempty"`
+	}{alias: alias(msg)}
+
+	if msg.UnfurlLinks || msg.unfurlLinksSet {
+		out.UnfurlLinks = &msg.UnfurlLinks
+	}
+	if msg.UnfurlMedia || msg.unfurlMediaSet {
+		out.UnfurlMedia = &msg.UnfurlMedia
+	}
+
+	return json.Marshal(out)
 }
 
 func PostWebhook(url string, msg *WebhookMessage) error {
@@ -40,6 +65,23 @@ func PostWebhookCustomHTTP(url string, httpClient *http.Client, msg *WebhookMess
 }
 
 func PostWebhookCustomHTTPContext(ctx context.Context, url string, httpClient *http.Client, msg *WebhookMessage) error {
+	return postWebhook(ctx, httpClient, url, msg)
+}
+
+// PostWebhook posts a message to an incoming webhook with the HTTP client of api, which goes
+// through its request middlewares.
+// For more details, see PostWebhookContext documentation.
+func (api *Client) PostWebhook(url string, msg *WebhookMessage) error {
+	return api.PostWebhookContext(context.Background(), url, msg)
+}
+
+// PostWebhookContext posts a message to an incoming webhook with the HTTP client of api, which
+// goes through its request middlewares, with a custom context.
+func (api *Client) PostWebhookContext(ctx context.Context, url string, msg *WebhookMessage) error {
+	return postWebhook(ctx, api.httpclient, url, msg)
+}
+
+func postWebhook(ctx context.Context, httpClient httpClient, url string, msg *WebhookMessage) error {
 	raw, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("marshal failed: %w", err)